@@ -0,0 +1,506 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxALSSize returns the largest almost locked set searched on the receiver grid (the cells of larger sets hold too
+// many candidates to make useful deductions, and enumerating them gets slow on large grids)
+func (s Sudoku) maxALSSize() int {
+	if s.size-1 < 8 {
+		return s.size - 1
+	}
+	return 8
+}
+
+// AlmostLockedSets returns the almost locked sets of all houses: N unsolved cells of a house holding N+1 candidates
+// altogether (a bivalue cell is an almost locked set of size 1). Sets lying both in a box and in a line are only
+// returned once, for the box
+func (s *Sudoku) AlmostLockedSets() []ALS {
+	grid := s.Candidates()
+	maxSize := s.maxALSSize()
+	res := []ALS{}
+	for _, house := range s.Houses() {
+		options := Options{}
+		for _, cell := range s.HouseCells(house) {
+			if grid.Get(cell.Row, cell.Col) != 0 {
+				options = append(options, grid.option(cell))
+			}
+		}
+		chosen := Options{}
+		var search func(start int, union ValueSet)
+		search = func(start int, union ValueSet) {
+			if len(chosen) > 0 && union.Length() == len(chosen)+1 && (house.Kind == HouseBox || !s.inOneBox(chosen)) {
+				res = append(res, ALS{House: house, Options: append(Options{}, chosen...), Values: union})
+			}
+			if len(chosen) == maxSize {
+				return
+			}
+			for i := start; i < len(options); i++ {
+				u := union.Union(options[i].option)
+				if u.Length() > maxSize+1 {
+					continue
+				}
+				chosen = append(chosen, options[i])
+				search(i+1, u)
+				chosen = chosen[:len(chosen)-1]
+			}
+		}
+		search(0, 0)
+	}
+	return res
+}
+
+// inOneBox returns true if all given options are in the same box
+func (s Sudoku) inOneBox(options Options) bool {
+	box := s.geo.house(HouseBox, options[0].row, options[0].col)
+	for _, option := range options[1:] {
+		if s.geo.house(HouseBox, option.row, option.col) != box {
+			return false
+		}
+	}
+	return true
+}
+
+// alsSet is an almost locked set, with the cells of each of its values and the houses holding them, precomputed for
+// searches combining many sets
+type alsSet struct {
+	ALS
+	indexes    []int               // cell indexes, in ascending order
+	valueCells [maxSize + 1][]Cell // cells having each value as candidate
+	houses     [maxSize + 1][3]int // for each value, index by kind of the house holding all its cells, -1 if none
+}
+
+// alsSets returns the almost locked sets of the receiver, with their precomputed data
+func (s *Sudoku) alsSets() []*alsSet {
+	res := []*alsSet{}
+	for _, a := range s.AlmostLockedSets() {
+		set := &alsSet{ALS: a}
+		for _, option := range a.Options {
+			cell := option.cell()
+			set.indexes = append(set.indexes, cell.Col+cell.Row*s.size)
+			option.option.Each(func(v int) {
+				set.valueCells[v] = append(set.valueCells[v], cell)
+			})
+		}
+		a.Values.Each(func(v int) {
+			cells := set.valueCells[v]
+			for kind := range set.houses[v] {
+				set.houses[v][kind] = s.geo.house(HouseKind(kind), cells[0].Row, cells[0].Col).Index
+				for _, cell := range cells[1:] {
+					if s.geo.house(HouseKind(kind), cell.Row, cell.Col).Index != set.houses[v][kind] {
+						set.houses[v][kind] = -1
+						break
+					}
+				}
+			}
+		})
+		res = append(res, set)
+	}
+	return res
+}
+
+// contains returns true if cell is one of the receiver cells
+func (a *alsSet) contains(cell Cell) bool {
+	for _, option := range a.Options {
+		if option.cell() == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps returns true if the receiver and b share a cell
+func (a *alsSet) overlaps(b *alsSet) bool {
+	i, j := 0, 0
+	for i < len(a.indexes) && j < len(b.indexes) {
+		switch {
+		case a.indexes[i] == b.indexes[j]:
+			return true
+		case a.indexes[i] < b.indexes[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return false
+}
+
+// cellsOf returns the cells of all given sets having value as candidate
+func cellsOf(value int, sets ...*alsSet) []Cell {
+	res := []Cell{}
+	for _, set := range sets {
+		res = append(res, set.valueCells[value]...)
+	}
+	return res
+}
+
+// restrictedCommons returns the restricted common candidates of non overlapping sets a and b: values of both sets
+// whose cells in a all see their cells in b (cells seeing each other being always in the same house, all these cells
+// are in one house). Such a value can't be set in both sets, so one of them is a locked set without it
+func (s *Sudoku) restrictedCommons(a, b *alsSet) ValueSet {
+	res := NewValueSet()
+	a.Values.Intersection(b.Values).Each(func(v int) {
+		for kind, index := range a.houses[v] {
+			if index >= 0 && b.houses[v][kind] == index {
+				res.Add(v)
+				return
+			}
+		}
+	})
+	return res
+}
+
+// removeSeeing removes value from the cells seeing all given cells, and returns true if some were removed. Reason is
+// only computed when needed
+func (s *Sudoku) removeSeeing(step *Step, value int, cells []Cell, reason func() string) bool {
+	grid := s.Candidates()
+	found := false
+	for _, cell := range s.seenByAll(cells) {
+		if grid.Has(cell.Row, cell.Col, value) {
+			s.removeCandidates(step, cell, NewValueSet(value), reason())
+			found = true
+		}
+	}
+	return found
+}
+
+// alsString returns given sets, separated by slashes
+func alsString(sets ...*alsSet) string {
+	res := []string{}
+	for _, set := range sets {
+		res = append(res, set.String())
+	}
+	return strings.Join(res, " / ")
+}
+
+// ResolveALSXZOptions based on https://www.sudokuwiki.org/Almost_Locked_Sets
+//
+// Two almost locked sets A and B sharing a restricted common candidate X: X is set in at most one of them, so the
+// other is a locked set. Another value Z of both sets is then set in A or in B, and can be removed from the cells
+// seeing all Z cells of both sets. When the sets share two restricted common candidates (doubly linked), both sets
+// are locked sets once the two values removed: these values can be removed from the cells seeing all their cells in
+// both sets, and the other values of each set from the cells seeing all their cells in this set
+func (s *Sudoku) ResolveALSXZOptions() Step {
+	step := Step{Technique: "ALS-XZ"}
+	sets := s.alsSets()
+	for i, a := range sets {
+		for _, b := range sets[i+1:] {
+			if a.overlaps(b) {
+				continue
+			}
+			rccs := s.restrictedCommons(a, b)
+			found := false
+			switch rccs.Length() {
+			case 0:
+				continue
+			case 2:
+				reason := func() string {
+					return fmt.Sprintf("doubly linked ALS %s, restricted commons %s", alsString(a, b), rccs.String())
+				}
+				rccs.Each(func(x int) {
+					found = s.removeSeeing(&step, x, cellsOf(x, a, b), reason) || found
+				})
+				for _, set := range []*alsSet{a, b} {
+					set.Values.Difference(rccs).Each(func(z int) {
+						found = s.removeSeeing(&step, z, cellsOf(z, set), reason) || found
+					})
+				}
+			default:
+				rccs.Each(func(x int) {
+					reason := func() string {
+						return fmt.Sprintf("ALS %s, restricted common %s", alsString(a, b), NewValueSet(x).String())
+					}
+					a.Values.Intersection(b.Values).Difference(NewValueSet(x)).Each(func(z int) {
+						found = s.removeSeeing(&step, z, cellsOf(z, a, b), reason) || found
+					})
+				})
+			}
+			if found {
+				step.addALS(a.ALS, b.ALS)
+			}
+		}
+	}
+	return step
+}
+
+// ResolveALSXYWingOptions based on https://www.sudokuwiki.org/ALS-XY-Wing
+//
+// Three almost locked sets A, B and C, A and C sharing a restricted common candidate X, B and C sharing another
+// restricted common candidate Y: if A doesn't hold X, C holds X so doesn't hold Y, and B holds Y. Either A or B is
+// then a locked set, and a value Z of both A and B can be removed from the cells seeing all Z cells of A and B
+func (s *Sudoku) ResolveALSXYWingOptions() Step {
+	step := Step{Technique: "ALS-XY-Wing"}
+	sets := s.alsSets()
+	// restricted commons of each set with the non overlapping sets
+	type link struct {
+		set  *alsSet
+		rccs ValueSet
+	}
+	links := make([][]link, len(sets))
+	for i, a := range sets {
+		for j := i + 1; j < len(sets); j++ {
+			if a.overlaps(sets[j]) {
+				continue
+			}
+			if rccs := s.restrictedCommons(a, sets[j]); rccs != 0 {
+				links[i] = append(links[i], link{set: sets[j], rccs: rccs})
+				links[j] = append(links[j], link{set: a, rccs: rccs})
+			}
+		}
+	}
+	for ci, c := range sets {
+		for i, la := range links[ci] {
+			for _, lb := range links[ci][i+1:] {
+				a, b := la.set, lb.set
+				common := a.Values.Intersection(b.Values)
+				if common == 0 || a.overlaps(b) {
+					continue
+				}
+				la.rccs.Each(func(x int) {
+					lb.rccs.Difference(NewValueSet(x)).Each(func(y int) {
+						reason := func() string {
+							return fmt.Sprintf("ALS-XY-Wing %s, pivot %s, restricted commons %s / %s", alsString(a, b), c.String(), NewValueSet(x).String(), NewValueSet(y).String())
+						}
+						found := false
+						common.Difference(NewValueSet(x, y)).Each(func(z int) {
+							found = s.removeSeeing(&step, z, cellsOf(z, a, b), reason) || found
+						})
+						if found {
+							step.addALS(a.ALS, c.ALS, b.ALS)
+						}
+					})
+				})
+			}
+		}
+	}
+	return step
+}
+
+// ResolveDeathBlossomOptions based on https://www.sudokuwiki.org/Death_Blossom
+//
+// A stem cell and, for each of its candidates, an almost locked set (petal) having this value as candidate in cells
+// all seeing the stem. Whatever the stem value, the petal of this value can't hold it and is a locked set: a value Z
+// of all petals (not a stem candidate) can be removed from the cells seeing all Z cells of the petals
+func (s *Sudoku) ResolveDeathBlossomOptions() Step {
+	step := Step{Technique: "Death Blossom"}
+	grid := s.Candidates()
+	sets := s.alsSets()
+	for _, option := range grid.Options() {
+		stem := option.cell()
+		values := option.GetValues()
+		if len(values) < 2 {
+			continue
+		}
+		// candidate petals of each stem value
+		petals := make([][]*alsSet, len(values))
+		for i, v := range values {
+		Sets:
+			for _, set := range sets {
+				if !set.Values.Has(v) || set.Values.Difference(option.option) == 0 || set.contains(stem) {
+					continue
+				}
+				for _, cell := range set.valueCells[v] {
+					if !s.geo.sees(cell, stem) {
+						continue Sets
+					}
+				}
+				petals[i] = append(petals[i], set)
+			}
+		}
+		chosen := []*alsSet{}
+		var search func(i int, common ValueSet)
+		search = func(i int, common ValueSet) {
+			if i == len(values) {
+				reason := func() string {
+					parts := []string{}
+					for j, petal := range chosen {
+						parts = append(parts, fmt.Sprintf("%s: %s", NewValueSet(values[j]).String(), petal.String()))
+					}
+					return fmt.Sprintf("death blossom stem %s, petals %s", option.String(), strings.Join(parts, " / "))
+				}
+				found := false
+				common.Each(func(z int) {
+					found = s.removeSeeing(&step, z, cellsOf(z, chosen...), reason) || found
+				})
+				if found {
+					for _, petal := range chosen {
+						step.addALS(petal.ALS)
+					}
+				}
+				return
+			}
+		Petals:
+			for _, petal := range petals[i] {
+				c := common.Intersection(petal.Values)
+				if c == 0 {
+					continue
+				}
+				for _, other := range chosen {
+					if petal.overlaps(other) {
+						continue Petals
+					}
+				}
+				chosen = append(chosen, petal)
+				search(i+1, c)
+				chosen = chosen[:len(chosen)-1]
+			}
+		}
+		search(0, fullValueSet(s.size).Difference(option.option))
+	}
+	return step
+}
+
+// maxSueDeCoqCrossing is the largest number of box and line intersection cells of a Sue de Coq
+const maxSueDeCoqCrossing = 3
+
+// maxSueDeCoqRest returns the largest number of line cells, and of box cells, outside of the intersection of a Sue de
+// Coq searched on the receiver grid
+func (s Sudoku) maxSueDeCoqRest() int {
+	if s.size/2 < 4 {
+		return s.size / 2
+	}
+	return 4
+}
+
+// ResolveSueDeCoqOptions based on https://www.sudokuwiki.org/Sue_De_Coq
+//
+// Cells C of a box and line intersection, cells L of the line and cells B of the box (outside of the intersection),
+// L and B having no common candidate, such that all these cells hold as many values as cells: each value is set in
+// one of the cells. The values of L, and those of C not in B, are set in the line within C or L, and can be removed
+// from its other cells. The values of B, and those of C not in L, can likewise be removed from the other cells of the
+// box.
+//
+// C is searched with 2 or 3 cells, and L and B with at most maxSueDeCoqRest cells each: larger patterns are rare, and
+// enumerating them is exponential in the grid size
+func (s *Sudoku) ResolveSueDeCoqOptions() Step {
+	step := Step{Technique: "Sue de Coq"}
+	grid := s.Candidates()
+	maxRest := s.maxSueDeCoqRest()
+	for _, box := range s.housesOf(HouseBox) {
+		boxCells := s.HouseCells(box)
+		for _, kind := range []HouseKind{HouseRow, HouseColumn} {
+			// lines crossing the box
+			lines := []House{}
+			for _, cell := range boxCells {
+				if line := s.geo.house(kind, cell.Row, cell.Col); !containsHouse(lines, line) {
+					lines = append(lines, line)
+				}
+			}
+			for _, line := range lines {
+				var crossing, lineRest, boxRest Options
+				for _, cell := range s.HouseCells(line) {
+					if grid.Get(cell.Row, cell.Col) == 0 {
+						continue
+					}
+					if s.geo.house(HouseBox, cell.Row, cell.Col) == box {
+						crossing = append(crossing, grid.option(cell))
+					} else {
+						lineRest = append(lineRest, grid.option(cell))
+					}
+				}
+				for _, cell := range boxCells {
+					if grid.Get(cell.Row, cell.Col) != 0 && s.geo.house(kind, cell.Row, cell.Col) != line {
+						boxRest = append(boxRest, grid.option(cell))
+					}
+				}
+				forEachOptionsSubset(crossing, maxSueDeCoqCrossing, func(c Options, cValues ValueSet) bool {
+					// extra candidates of the crossing, which must be set in the line and box cells
+					extra := cValues.Length() - len(c)
+					if len(c) < 2 || extra < 2 {
+						return true
+					}
+					lineOptions := optionsSharing(lineRest, cValues, 0)
+					forEachOptionsSubset(lineOptions, maxRest, func(l Options, lValues ValueSet) bool {
+						// candidates of the line cells outside of the crossing are set in the line cells, or
+						// in place of extra crossing candidates set in box cells
+						lineExtra := lValues.Difference(cValues).Length()
+						if lineExtra > 2*maxRest-extra {
+							return false
+						}
+						if len(l) == 0 {
+							return true
+						}
+						boxOptions := optionsSharing(boxRest, cValues, lValues)
+						forEachOptionsSubset(boxOptions, maxRest, func(b Options, bValues ValueSet) bool {
+							boxExtra := bValues.Difference(cValues).Length()
+							if lineExtra+boxExtra > len(l)+maxRest-extra {
+								return false
+							}
+							if len(b) == 0 || cValues.Union(lValues).Union(bValues).Length() != len(c)+len(l)+len(b) {
+								return true
+							}
+							reason := fmt.Sprintf("sue de coq %s in %s / %s in %s / %s in %s", c.String(), box.String(), l.String(), line.String(), b.String(), box.String())
+							lineValues := lValues.Union(cValues.Difference(bValues))
+							for _, cell := range s.HouseCells(line) {
+								if !optionsContain(c, cell) && !optionsContain(l, cell) {
+									s.removeCandidates(&step, cell, lineValues, reason)
+								}
+							}
+							boxValues := bValues.Union(cValues.Difference(lValues))
+							for _, cell := range boxCells {
+								if !optionsContain(c, cell) && !optionsContain(b, cell) {
+									s.removeCandidates(&step, cell, boxValues, reason)
+								}
+							}
+							return true
+						})
+						return true
+					})
+					return true
+				})
+			}
+		}
+	}
+	return step
+}
+
+// forEachOptionsSubset calls fn for each subset of at most maxSize options (the empty one included), with the union
+// of their candidates. A subset is extended with more options only while fn returns true
+func forEachOptionsSubset(options Options, maxSize int, fn func(subset Options, union ValueSet) bool) {
+	subset := Options{}
+	var search func(start int, union ValueSet)
+	search = func(start int, union ValueSet) {
+		if !fn(subset, union) || len(subset) == maxSize {
+			return
+		}
+		for i := start; i < len(options); i++ {
+			subset = append(subset, options[i])
+			search(i+1, union.Union(options[i].option))
+			subset = subset[:len(subset)-1]
+		}
+	}
+	search(0, 0)
+}
+
+// optionsSharing returns given options having a candidate in values, and none in excluded
+func optionsSharing(options Options, values, excluded ValueSet) Options {
+	res := Options{}
+	for _, option := range options {
+		if option.option.Intersection(values) != 0 && option.option.Intersection(excluded) == 0 {
+			res = append(res, option)
+		}
+	}
+	return res
+}
+
+// optionsContain returns true if one of given options is about cell
+func optionsContain(options Options, cell Cell) bool {
+	for _, option := range options {
+		if option.cell() == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// containsHouse returns true if house is one of given houses
+func containsHouse(houses []House, house House) bool {
+	for _, h := range houses {
+		if h == house {
+			return true
+		}
+	}
+	return false
+}
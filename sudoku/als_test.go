@@ -0,0 +1,127 @@
+package sudoku
+
+import "testing"
+
+func TestSudoku_AlmostLockedSets(t *testing.T) {
+	s := New(9)
+	restrictCell(&s, 0, 0, 1, 2)    // A1, bivalue
+	restrictCell(&s, 0, 1, 2, 3)    // B1
+	restrictCell(&s, 4, 0, 1, 2, 4) // A5
+
+	found := map[string]int{}
+	for _, set := range s.AlmostLockedSets() {
+		if set.Values.Length() != len(set.Options)+1 {
+			t.Errorf("%s: %d cells with values %s", set.String(), len(set.Options), set.Values.String())
+		}
+		found[set.String()]++
+	}
+	for _, expect := range []string{
+		"A1[1, 2] in box 1",                 // bivalue cell, once for its box
+		"A1[1, 2], B1[2, 3] in box 1",       // in box 1 and row 1, once for the box
+		"A1[1, 2], A5[1, 2, 4] in column A", // in a line only
+	} {
+		if found[expect] != 1 {
+			t.Errorf("expected almost locked set %s once, found %d times", expect, found[expect])
+		}
+	}
+	for _, unexpected := range []string{"A1[1, 2] in row 1", "A1[1, 2], B1[2, 3] in row 1", "A5[1, 2, 4] in box 4"} {
+		if found[unexpected] > 0 {
+			t.Errorf("unexpected almost locked set %s", unexpected)
+		}
+	}
+}
+
+func TestSudoku_ResolveALSOptions_Puzzles(t *testing.T) {
+	checkTechnique(t, StrategyALSXZ,
+		".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4",
+		".....3....2..6.45.8.....3......7.21..6...1.....78....9.7.1..8..........391.5..6.2")
+	checkTechnique(t, StrategyALSXYWing,
+		"7...5.2.1.2...6.3.4......5..5..27.......6...93....4....6...9..5.......4227....8.6",
+		"..9.6.....21..86..7..29..3....157......3..8..2.7.....3...9...4.......2878....2.6.")
+	checkTechnique(t, StrategyDeathBlossom,
+		"..9.6.....21..86..7..29..3....157......3..8..2.7.....3...9...4.......2878....2.6.",
+		"47...85.......24.3.68..4.2.........8...5.3.....1.7....6.7.49..28.....9...3......1")
+	checkTechnique(t, StrategySueDeCoq,
+		".3...6.......58..757.2...3..6...2...9.....4....1....6....7...18....3...9.468....2",
+		"...65....7....16522..7..8....9.....3.......8..7.83.1......2...541.36.2..3....54..")
+}
+
+// testSparseGrid16 is a 16x16 grid with few givens, whose cells hold many candidates
+const testSparseGrid16 = "...........1..C.1.3..DE.6.4......7.....G...F...B.CD.........1....9.....4A...DE.........8..G...B.7.58....1.....6.F.B..5.D.9.8...A....E......7..F......C..E.1.B...CE.B.1.....4A..3...7.BF...C..D.18...46.C.G.......3..B.........D......9..FC...5.6....A..5........"
+
+func TestForEachOptionsSubset(t *testing.T) {
+	s := New(16)
+	options := s.Candidates().Options()[:12]
+	for _, tc := range []struct {
+		maxSize, extendSize, calls int
+	}{
+		{4, 4, 1 + 12 + 66 + 220 + 495}, // subsets of at most 4 options out of 12
+		{4, 1, 1 + 12 + 66},             // subsets of 2 options are not extended
+		{0, 4, 1},
+	} {
+		calls := 0
+		forEachOptionsSubset(options, tc.maxSize, func(subset Options, union ValueSet) bool {
+			calls++
+			if len(subset) > tc.maxSize {
+				t.Errorf("subset %s larger than %d options", subset.String(), tc.maxSize)
+			}
+			return len(subset) <= tc.extendSize
+		})
+		if calls != tc.calls {
+			t.Errorf("max size %d, extended up to %d: got %d subsets, expected %d", tc.maxSize, tc.extendSize, calls, tc.calls)
+		}
+	}
+}
+
+func TestSudoku_ResolveSueDeCoqOptions_Large(t *testing.T) {
+	// line and box parts are bounded on large grids: a search on all subsets of 12 cells wouldn't complete
+	for _, tc := range []struct {
+		size, maxRest int
+	}{{4, 2}, {6, 3}, {9, 4}, {16, 4}, {25, 4}} {
+		if maxRest := New(tc.size).maxSueDeCoqRest(); maxRest != tc.maxRest {
+			t.Errorf("size %d: got %d cells at most, expected %d", tc.size, maxRest, tc.maxRest)
+		}
+	}
+	sparse, err := Parse(testSparseGrid16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sparse.ResolveSueDeCoqOptions()
+	empty := New(16)
+	if step := empty.ResolveSueDeCoqOptions(); step.Found() {
+		t.Errorf("unexpected deduction on an empty grid %s", step.String())
+	}
+}
+
+func TestSudoku_ResolveALSXZOptions_Records(t *testing.T) {
+	s, err := Parse(".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	Solver{Observer: ObserverFunc(func(e Event) {
+		if e.Kind != EventStep || e.Depth > 0 || e.Step.Technique != StrategyALSXZ {
+			return
+		}
+		found = true
+		if len(e.Step.ALS) == 0 || len(e.Step.ALS)%2 != 0 {
+			t.Errorf("expected pairs of almost locked sets, got %d in %s", len(e.Step.ALS), e.Step.String())
+		}
+		for _, a := range e.Step.ALS {
+			if a.Values.Length() != len(a.Options)+1 {
+				t.Errorf("%s is not an almost locked set", a.String())
+			}
+		}
+	})}.Solve(&s)
+	if !found {
+		t.Error("ALS-XZ was not used")
+	}
+}
+
+func BenchmarkSudoku_ResolveSueDeCoqOptions(b *testing.B) {
+	puzzle, _ := Parse(testSparseGrid16)
+	for i := 0; i < b.N; i++ {
+		s := puzzle.Clone()
+		s.ResolveSueDeCoqOptions()
+	}
+}
@@ -0,0 +1,180 @@
+package sudoku
+
+// CandidateGrid holds the candidate values (pencil marks) of each cell of a sudoku.
+//
+// It is attached to a Sudoku (see Sudoku.Candidates), updated incrementally when a value is set (the value is removed
+// from the candidates of all peer cells) and when candidates are eliminated by solving techniques
+type CandidateGrid struct {
+	size   int
+	geo    *geometry
+	cells  []ValueSet // candidates of each cell, row by row
+	placed []bool     // true for cells with a defined value
+	givens []bool     // true for cells whose value was defined when the grid was computed (puzzle givens)
+}
+
+// newCandidateGrid returns the CandidateGrid of given sudoku, computed from its values
+func newCandidateGrid(s Sudoku) *CandidateGrid {
+	g := &CandidateGrid{
+		size:   s.size,
+		geo:    s.geo,
+		cells:  make([]ValueSet, len(s.values)),
+		placed: make([]bool, len(s.values)),
+		givens: make([]bool, len(s.values)),
+	}
+	for r := 0; r < s.size; r++ {
+		for c := 0; c < s.size; c++ {
+			i := c + r*s.size
+			if s.values[i] != valueUndef {
+				g.placed[i] = true
+				g.givens[i] = true
+				g.cells[i] = NewValueSet()
+				continue
+			}
+			g.cells[i] = s.GetValid(r, c)
+		}
+	}
+	return g
+}
+
+// Clone returns a deep copy of receiver
+func (g *CandidateGrid) Clone() *CandidateGrid {
+	ng := &CandidateGrid{
+		size:   g.size,
+		geo:    g.geo,
+		cells:  make([]ValueSet, len(g.cells)),
+		placed: make([]bool, len(g.placed)),
+		givens: make([]bool, len(g.givens)),
+	}
+	copy(ng.cells, g.cells)
+	copy(ng.placed, g.placed)
+	copy(ng.givens, g.givens)
+	return ng
+}
+
+// Get returns the candidates of cell (row, col). Result is empty for a cell with a defined value
+func (g *CandidateGrid) Get(row, col int) ValueSet {
+	return g.cells[col+row*g.size]
+}
+
+// Has returns true if value is a candidate of cell (row, col)
+func (g *CandidateGrid) Has(row, col, value int) bool {
+	return g.cells[col+row*g.size].Has(value)
+}
+
+// Eliminate removes given values from the candidates of cell (row, col), and returns the values actually removed
+func (g *CandidateGrid) Eliminate(row, col int, values ValueSet) []int {
+	i := col + row*g.size
+	removed := g.cells[i].Intersection(values)
+	g.cells[i].RemoveSet(removed)
+	return removed.GetValues()
+}
+
+// Options returns the candidates of all cells without defined value, as a slice of Option sorted from option with
+// the fewest possible values first.
+//
+// Returned Options are a snapshot: eliminations must be applied on the receiver
+func (g *CandidateGrid) Options() Options {
+	res := Options{}
+	for i, cell := range g.cells {
+		if g.placed[i] {
+			continue
+		}
+		res = append(res, Option{
+			row:    i / g.size,
+			col:    i % g.size,
+			option: cell,
+		})
+	}
+	res.SortByLength()
+	return res
+}
+
+// isGiven returns true if the value of given cell was defined when the receiver was computed (a puzzle given, not a
+// solved cell)
+func (g *CandidateGrid) isGiven(cell Cell) bool {
+	return g.givens[cell.Col+cell.Row*g.size]
+}
+
+// Contradiction returns true if a cell without defined value has no candidate left
+func (g *CandidateGrid) Contradiction() bool {
+	for i, cell := range g.cells {
+		if !g.placed[i] && cell == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// place records value set on cell (row, col): the cell has no more candidates, and value is removed from peer cells
+func (g *CandidateGrid) place(value, row, col int) {
+	i := col + row*g.size
+	g.placed[i] = true
+	g.cells[i] = NewValueSet()
+	for _, peer := range g.geo.peers[i] {
+		g.cells[peer.Col+peer.Row*g.size].RemoveValue(value)
+	}
+}
+
+// clear records value removed from cell (row, col) of s: the cell gets the candidates allowed by the values of s, and
+// value is a candidate again of peer cells having no other peer holding it. Other eliminations are kept
+func (g *CandidateGrid) clear(s Sudoku, row, col, value int) {
+	i := col + row*g.size
+	g.placed[i] = false
+	g.givens[i] = false
+	g.cells[i] = s.GetValid(row, col)
+	for _, peer := range g.geo.peers[i] {
+		j := peer.Col + peer.Row*g.size
+		if !g.placed[j] && s.GetValid(peer.Row, peer.Col).Has(value) {
+			g.cells[j].Add(value)
+		}
+	}
+}
+
+// Candidates returns the CandidateGrid attached to the receiver, computing it from values if needed
+func (s *Sudoku) Candidates() *CandidateGrid {
+	if s.candidates == nil {
+		s.candidates = newCandidateGrid(*s)
+	}
+	return s.candidates
+}
+
+// option returns the Option giving the candidates of given cell
+func (g *CandidateGrid) option(cell Cell) Option {
+	return Option{row: cell.Row, col: cell.Col, option: g.Get(cell.Row, cell.Col)}
+}
+
+// valueCells returns the cells of given house having value as candidate
+func (s *Sudoku) valueCells(h House, value int) []Cell {
+	grid := s.Candidates()
+	res := []Cell{}
+	for _, cell := range s.HouseCells(h) {
+		if grid.Has(cell.Row, cell.Col, value) {
+			res = append(res, cell)
+		}
+	}
+	return res
+}
+
+// eliminate removes given values from the candidates of given cell, recording the removed values in step.
+// It returns true if some values were removed
+func (s *Sudoku) eliminate(step *Step, cell Cell, values ValueSet) bool {
+	removed := s.Candidates().Eliminate(cell.Row, cell.Col, values)
+	if len(removed) == 0 {
+		return false
+	}
+	step.Eliminations = append(step.Eliminations, Elimination{Cell: cell, Values: removed})
+	step.eliminationDetails = append(step.eliminationDetails, len(step.Details))
+	return true
+}
+
+// place sets value on given cell, recording the placement in step.
+// It returns false if value is no longer a candidate of the cell (value is then not set)
+func (s *Sudoku) place(step *Step, cell Cell, value int) bool {
+	if !s.Candidates().Has(cell.Row, cell.Col, value) {
+		return false
+	}
+	s.SetValue(value, cell.Row, cell.Col)
+	step.Placements = append(step.Placements, Placement{Cell: cell, Value: value})
+	step.placementDetails = append(step.placementDetails, len(step.Details))
+	return true
+}
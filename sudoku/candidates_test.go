@@ -0,0 +1,94 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCandidateGrid_Place(t *testing.T) {
+	s, err := Parse(testPuzzles[10].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := s.Candidates()
+	for _, p := range []Placement{{Cell{0, 0}, 1}, {Cell{4, 4}, 5}, {Cell{8, 8}, 2}} {
+		if !grid.Has(p.Row, p.Col, p.Value) {
+			t.Fatalf("%s should be a candidate", p.String())
+		}
+		s.SetValue(p.Value, p.Row, p.Col)
+	}
+	// incremental update must match candidates computed from scratch
+	expect := newCandidateGrid(s)
+	if !reflect.DeepEqual(grid.Options(), expect.Options()) {
+		t.Errorf("incremental candidates\n%s\ndiffer from computed ones\n%s", grid.Options().String(), expect.Options().String())
+	}
+
+	// clearing a value restores candidates
+	s.SetValue(valueUndef, 4, 4)
+	if !grid.Has(4, 4, 5) || !grid.Has(4, 0, 5) {
+		t.Errorf("5 should be a candidate of E5 and A5 again: %s", grid.Options().String())
+	}
+}
+
+func TestCandidateGrid_Clear(t *testing.T) {
+	s, err := Parse(testPuzzles[10].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := s.Candidates()
+	s.SetValue(5, 4, 4)
+	// eliminations in E4 (peer of E5) and in I9 (not a peer) must be kept when E5 is cleared
+	kept := map[Cell]int{}
+	for _, cell := range []Cell{{3, 4}, {8, 8}} {
+		values := grid.Get(cell.Row, cell.Col).Difference(NewValueSet(5)).GetValues()
+		if len(values) < 2 {
+			t.Fatalf("%s should have several candidates", cell.String())
+		}
+		kept[cell] = values[0]
+		grid.Eliminate(cell.Row, cell.Col, NewValueSet(values[0]))
+	}
+
+	s.SetValue(valueUndef, 4, 4)
+	if !grid.Has(4, 4, 5) || !grid.Has(4, 0, 5) || !grid.Has(3, 4, 5) {
+		t.Errorf("5 should be a candidate of E5, A5 and E4 again: %s", grid.Options().String())
+	}
+	for cell, v := range kept {
+		if grid.Has(cell.Row, cell.Col, v) {
+			t.Errorf("elimination of %d from %s should be kept", v, cell.String())
+		}
+	}
+	// incremental update must match candidates computed from scratch, but for the kept eliminations
+	expect := newCandidateGrid(s)
+	for cell, v := range kept {
+		expect.Eliminate(cell.Row, cell.Col, NewValueSet(v))
+	}
+	if !reflect.DeepEqual(grid.Options(), expect.Options()) {
+		t.Errorf("incremental candidates\n%s\ndiffer from computed ones\n%s", grid.Options().String(), expect.Options().String())
+	}
+}
+
+func TestCandidateGrid_Eliminate(t *testing.T) {
+	s, err := Parse(testPuzzles[10].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := s.Candidates()
+	before := grid.Get(0, 0)
+	removed := grid.Eliminate(0, 0, NewValueSet(1, 2, 7))
+	if !reflect.DeepEqual(removed, before.Difference(grid.Get(0, 0)).GetValues()) || len(removed) == 0 {
+		t.Errorf("unexpected removed values %v from %s", removed, before.String())
+	}
+
+	// eliminations are kept when other cells are set, and by Clone
+	s.SetValue(9, 2, 8)
+	s2 := s.Clone()
+	for _, v := range []int{1, 2, 7} {
+		if s.Candidates().Has(0, 0, v) || s2.Candidates().Has(0, 0, v) {
+			t.Errorf("%d should not be a candidate of A1 anymore", v)
+		}
+	}
+	s2.Candidates().Eliminate(0, 1, s2.Candidates().Get(0, 1))
+	if !s2.Candidates().Contradiction() || s.Candidates().Contradiction() {
+		t.Errorf("only clone should have a contradiction")
+	}
+}
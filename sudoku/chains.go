@@ -0,0 +1,267 @@
+package sudoku
+
+import "fmt"
+
+// maxChainLength is the maximum number of nodes of the chains searched
+const maxChainLength = 20
+
+// chainRules gives the links a chain technique may use: house links connect candidates of the same value in a
+// house, cell links connect candidates of the same cell
+type chainRules struct {
+	houseStrong, cellStrong bool
+	houseWeak, cellWeak     bool
+}
+
+var (
+	xChainRules  = chainRules{houseStrong: true, houseWeak: true}
+	xyChainRules = chainRules{cellStrong: true, houseWeak: true}
+	aicRules     = chainRules{houseStrong: true, cellStrong: true, houseWeak: true, cellWeak: true}
+)
+
+// candidateIndex returns the index of given candidate, from 0 to size^3-1
+func (s Sudoku) candidateIndex(c Candidate) int {
+	return (c.Col+c.Row*s.size)*s.size + c.Value - 1
+}
+
+// candidateOf returns the candidate of given index
+func (s Sudoku) candidateOf(index int) Candidate {
+	cell := index / s.size
+	return Candidate{Cell: Cell{Row: cell / s.size, Col: cell % s.size}, Value: index%s.size + 1}
+}
+
+// strongLinks returns the candidates strongly linked to c: if c is false, they are true
+func (s *Sudoku) strongLinks(c Candidate, rules chainRules) []Candidate {
+	grid := s.Candidates()
+	res := []Candidate{}
+	if rules.houseStrong {
+		for _, kind := range []HouseKind{HouseBox, HouseRow, HouseColumn} {
+			if cells := s.valueCells(s.geo.house(kind, c.Row, c.Col), c.Value); len(cells) == 2 {
+				other := cells[0]
+				if other == c.Cell {
+					other = cells[1]
+				}
+				res = append(res, Candidate{Cell: other, Value: c.Value})
+			}
+		}
+	}
+	if values := grid.Get(c.Row, c.Col); rules.cellStrong && values.Length() == 2 {
+		res = append(res, Candidate{Cell: c.Cell, Value: values.Difference(NewValueSet(c.Value)).First()})
+	}
+	return res
+}
+
+// weakLinks returns the candidates weakly linked to c: if c is true, they are false
+func (s *Sudoku) weakLinks(c Candidate, rules chainRules) []Candidate {
+	grid := s.Candidates()
+	res := []Candidate{}
+	if rules.houseWeak {
+		for _, peer := range s.Peers(c.Row, c.Col) {
+			if grid.Has(peer.Row, peer.Col, c.Value) {
+				res = append(res, Candidate{Cell: peer, Value: c.Value})
+			}
+		}
+	}
+	if rules.cellWeak {
+		grid.Get(c.Row, c.Col).Each(func(v int) {
+			if v != c.Value {
+				res = append(res, Candidate{Cell: c.Cell, Value: v})
+			}
+		})
+	}
+	return res
+}
+
+// weaklyLinked returns true if candidates a and b can't be both true
+func (s Sudoku) weaklyLinked(a, b Candidate) bool {
+	return a != b && (a.Value == b.Value && s.geo.sees(a.Cell, b.Cell) || a.Cell == b.Cell)
+}
+
+// seenCandidates returns the candidates of the grid weakly linked to all given nodes, except nodes themselves
+func (s *Sudoku) seenCandidates(nodes ...Candidate) []Candidate {
+	grid := s.Candidates()
+	res := []Candidate{}
+	isNode := make(map[Candidate]bool)
+	for _, n := range nodes {
+		isNode[n] = true
+	}
+	first := nodes[0]
+	candidates := []Candidate{}
+	for _, peer := range s.Peers(first.Row, first.Col) {
+		candidates = append(candidates, Candidate{Cell: peer, Value: first.Value})
+	}
+	grid.Get(first.Row, first.Col).Each(func(v int) {
+		candidates = append(candidates, Candidate{Cell: first.Cell, Value: v})
+	})
+Candidates:
+	for _, c := range candidates {
+		if isNode[c] || !grid.Has(c.Row, c.Col, c.Value) {
+			continue
+		}
+		for _, n := range nodes {
+			if !s.weaklyLinked(c, n) {
+				continue Candidates
+			}
+		}
+		res = append(res, c)
+	}
+	return res
+}
+
+// searchChains returns the shortest chain following given rules that makes deductions, or false if none is found.
+//
+// For each candidate A, implications are followed from "A is false": a strong link makes the next candidate true, a
+// weak link then makes the next one false. Reaching "Z is true" proves A or Z is true, so candidates weakly linked to
+// both are false. Reaching "A is true" proves A is true (discontinuous nice loop with two strong links). When Z is
+// weakly linked to A, the chain closes a continuous nice loop: each of its weak links then holds exactly one true
+// candidate, and candidates weakly linked to both ends of a weak link are false
+func (s *Sudoku) searchChains(rules chainRules) (Chain, bool) {
+	grid := s.Candidates()
+	nbStates := 2 * s.size * s.size * s.size
+	var best Chain
+	found := false
+	for _, option := range grid.Options() {
+		for _, v := range option.GetValues() {
+			start := Candidate{Cell: option.cell(), Value: v}
+			// states are (candidate index)*2 + 1 when candidate is true, parents give the previous state of each
+			// reached state
+			parent := make([]int, nbStates)
+			for i := range parent {
+				parent[i] = -1
+			}
+			startState := 2 * s.candidateIndex(start)
+			parent[startState] = startState
+			queue, length := []int{startState}, 1
+			for len(queue) > 0 && length < maxChainLength && (!found || length+1 < len(best.Nodes)) {
+				next := []int{}
+				for _, state := range queue {
+					node, on := s.candidateOf(state/2), state%2 == 1
+					links := s.strongLinks(node, rules)
+					if on {
+						links = s.weakLinks(node, rules)
+					}
+					for _, c := range links {
+						ns := 2*s.candidateIndex(c) + 1
+						if on {
+							ns--
+						}
+						if parent[ns] >= 0 {
+							continue
+						}
+						parent[ns] = state
+						next = append(next, ns)
+						if on { // weak link: chains end on strong links
+							continue
+						}
+						chain := Chain{Nodes: chainNodes(s, parent, ns)}
+						if s.chainDeductions(&chain, rules) && (!found || len(chain.Nodes) < len(best.Nodes)) {
+							best, found = chain, true
+						}
+					}
+				}
+				queue = next
+				length++
+			}
+		}
+	}
+	return best, found
+}
+
+// chainNodes returns the candidates of the chain ending at given state, from the start state
+func chainNodes(s *Sudoku, parent []int, state int) []Candidate {
+	res := []Candidate{}
+	for {
+		res = append([]Candidate{s.candidateOf(state / 2)}, res...)
+		if parent[state] == state {
+			return res
+		}
+		state = parent[state]
+	}
+}
+
+// chainDeductions sets the placements and eliminations proven by given chain, and returns true if there are some
+func (s *Sudoku) chainDeductions(chain *Chain, rules chainRules) bool {
+	first, last := chain.Nodes[0], chain.Nodes[len(chain.Nodes)-1]
+	if first == last {
+		chain.Placements = []Placement{{Cell: first.Cell, Value: first.Value}}
+		return true
+	}
+
+	distinct := make(map[Candidate]bool)
+	for _, n := range chain.Nodes {
+		distinct[n] = true
+	}
+	closing := rules.houseWeak && first.Value == last.Value && s.geo.sees(first.Cell, last.Cell) || rules.cellWeak && first.Cell == last.Cell
+	if closing && len(chain.Nodes) >= 4 && len(distinct) == len(chain.Nodes) {
+		chain.Loop = true
+		for i := 1; i < len(chain.Nodes); i += 2 {
+			weakEnd := first
+			if i+1 < len(chain.Nodes) {
+				weakEnd = chain.Nodes[i+1]
+			}
+			for _, c := range s.seenCandidates(chain.Nodes[i], weakEnd) {
+				if !distinct[c] {
+					chain.Eliminations = append(chain.Eliminations, Elimination{Cell: c.Cell, Values: []int{c.Value}})
+				}
+			}
+		}
+		if len(chain.Eliminations) > 0 {
+			return true
+		}
+		chain.Loop = false
+	}
+
+	for _, c := range s.seenCandidates(first, last) {
+		chain.Eliminations = append(chain.Eliminations, Elimination{Cell: c.Cell, Values: []int{c.Value}})
+	}
+	return len(chain.Eliminations) > 0
+}
+
+// resolveChain applies the shortest chain following given rules that makes deductions
+func (s *Sudoku) resolveChain(step *Step, rules chainRules) {
+	chain, found := s.searchChains(rules)
+	if !found {
+		return
+	}
+	grid := s.Candidates()
+	for _, p := range chain.Placements {
+		option := grid.option(p.Cell)
+		if s.place(step, p.Cell, p.Value) {
+			step.Details = append(step.Details, fmt.Sprintf("%s in %s (%s)", NewValueSet(p.Value).String(), option.String(), chain.String()))
+		}
+	}
+	for _, e := range chain.Eliminations {
+		option := grid.option(e.Cell)
+		if s.eliminate(step, e.Cell, NewValueSet(e.Values...)) {
+			step.Details = append(step.Details, fmt.Sprintf("%s from %s (%s)", NewValueSet(e.Values...).String(), option.String(), chain.String()))
+		}
+	}
+	step.Chains = append(step.Chains, chain)
+}
+
+// ResolveXChainOptions based on https://www.sudokuwiki.org/X_Cycles
+//
+// Chains on a single value, made of house links only (nice loops included)
+func (s *Sudoku) ResolveXChainOptions() Step {
+	step := Step{Technique: "X-Chain"}
+	s.resolveChain(&step, xChainRules)
+	return step
+}
+
+// ResolveXYChainOptions based on https://www.sudokuwiki.org/XY_Chains
+//
+// Chains of bivalue cells: strong links within cells, weak links between cells of the same value (nice loops
+// included)
+func (s *Sudoku) ResolveXYChainOptions() Step {
+	step := Step{Technique: "XY-Chain"}
+	s.resolveChain(&step, xyChainRules)
+	return step
+}
+
+// ResolveAICOptions based on https://www.sudokuwiki.org/Alternating_Inference_Chains
+//
+// Alternating inference chains mixing house and cell links (nice loops included)
+func (s *Sudoku) ResolveAICOptions() Step {
+	step := Step{Technique: "Alternating Inference Chain"}
+	s.resolveChain(&step, aicRules)
+	return step
+}
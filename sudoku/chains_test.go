@@ -0,0 +1,60 @@
+package sudoku
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChain_String(t *testing.T) {
+	for _, tc := range []struct {
+		chain  Chain
+		expect string
+	}{
+		{
+			Chain{Nodes: []Candidate{{Cell{2, 0}, 5}, {Cell{2, 6}, 5}, {Cell{7, 6}, 5}, {Cell{7, 6}, 2}, {Cell{7, 0}, 2}, {Cell{7, 0}, 5}}},
+			"(5)A3=(5)G3-(5=2)G8-(2=5)A8",
+		},
+		{
+			Chain{Nodes: []Candidate{{Cell{4, 2}, 5}, {Cell{4, 2}, 4}, {Cell{3, 1}, 4}, {Cell{3, 4}, 4}, {Cell{3, 4}, 7}, {Cell{4, 4}, 7}}},
+			"(5=4)C5-(4)B4=(4)E4-(7)E4=(7)E5",
+		},
+		{
+			Chain{Nodes: []Candidate{{Cell{0, 0}, 1}, {Cell{0, 4}, 1}, {Cell{4, 4}, 1}, {Cell{4, 0}, 1}}, Loop: true},
+			"(1)A1=(1)E1-(1)E5=(1)A5-(1)A1",
+		},
+	} {
+		if got := tc.chain.String(); got != tc.expect {
+			t.Errorf("got %q, expected %q", got, tc.expect)
+		}
+	}
+}
+
+func TestSudoku_ResolveXChainOptions_Loop(t *testing.T) {
+	s := New(9)
+	// continuous loop on 1 with conjugate pairs A1=E1 in row 1 and A5=E5 in row 5: 1 is in A1 and E5, or in E1 and
+	// A5, so it can be removed from the other cells of columns A and E
+	restrictLine(&s, HouseRow, 0, 1, 0, 4)
+	restrictLine(&s, HouseRow, 4, 1, 0, 4)
+
+	step := s.ResolveXChainOptions()
+	if len(step.Chains) != 1 || !step.Chains[0].Loop {
+		t.Fatalf("expected a nice loop, got %s", step.String())
+	}
+	if len(step.Eliminations) != 14 {
+		t.Errorf("expected 14 eliminations, got %s", step.String())
+	}
+	for _, el := range step.Eliminations {
+		if el.Col != 0 && el.Col != 4 {
+			t.Errorf("unexpected elimination %s", el.String())
+		}
+	}
+	if !strings.Contains(step.Details[0], "(1)A1=(1)E1-") {
+		t.Errorf("chain not reported: %s", step.Details[0])
+	}
+}
+
+func TestSudoku_ResolveChainOptions_Puzzles(t *testing.T) {
+	checkTechnique(t, StrategyXChain, "....41.5...73....6.5.6.2.3..1..6.7..5..7...1......5........4.9..3.1...749.4...2..")
+	checkTechnique(t, StrategyXYChain, "....76....124....5....1..8..7..32....29..86....86....34....1.56......31....36...2")
+	checkTechnique(t, StrategyAIC, "2...8..4.....21...3..9..7.2.52....6.9........8.1.....7.8..1........62.3.7...9...5")
+}
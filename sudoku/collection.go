@@ -0,0 +1,154 @@
+package sudoku
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// LineError reports a puzzle of a collection which can't be read, for another reason than an invalid character
+// (reported as a *ParseError)
+type LineError struct {
+	Line int   // line number (1-based) of the puzzle within the collection
+	Err  error // reason why the puzzle can't be read
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("sudoku: line %d: %s", e.Line, strings.TrimPrefix(e.Err.Error(), "sudoku: "))
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Reader streams puzzles from a collection file holding one puzzle per line (as in .sdm files).
+//
+// Each line holds a puzzle in one-line format, optionally followed by metadata (name, rating, ...) separated
+// from the puzzle by whitespace, ',' or ';'. Blank lines and lines starting with '#' are skipped.
+//
+// Puzzles get the default box geometry of their size (see New), unless the one-line format is followed by a box
+// geometry suffix giving the box width and height ("@2x3" for a 6x6 grid with boxes 2 columns wide and 3 rows high)
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+	meta    string
+}
+
+// NewReader returns a Reader reading puzzles from r
+func NewReader(r io.Reader) *Reader {
+	return &Reader{scanner: bufio.NewScanner(r)}
+}
+
+// Read returns the next puzzle of the collection, or io.EOF when no more puzzle is available.
+//
+// If a line can not be parsed, returned error reports the line number (as a *ParseError for invalid characters),
+// and reading can continue with next line
+func (r *Reader) Read() (Sudoku, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		grid, meta := line, ""
+		if pos := strings.IndexAny(line, " \t,;"); pos >= 0 {
+			grid, meta = line[:pos], strings.TrimLeft(line[pos:], " \t,;")
+		}
+		r.meta = meta
+
+		box := ""
+		if pos := strings.IndexByte(grid, '@'); pos >= 0 {
+			grid, box = grid[:pos], grid[pos+1:]
+		}
+
+		s, err := Parse(grid)
+		if err == nil && box != "" {
+			s, err = withBox(s, box)
+		}
+		if err != nil {
+			var perr *ParseError
+			if errors.As(err, &perr) {
+				perr.Line = r.line
+				return Sudoku{}, perr
+			}
+			return Sudoku{}, &LineError{Line: r.line, Err: err}
+		}
+		return s, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return Sudoku{}, err
+	}
+	return Sudoku{}, io.EOF
+}
+
+// withBox returns a copy of given puzzle with the box geometry given by a geometry suffix ("2x3")
+func withBox(s Sudoku, box string) (Sudoku, error) {
+	var width, height int
+	if n, err := fmt.Sscanf(box, "%dx%d", &width, &height); n != 2 || err != nil || fmt.Sprintf("%dx%d", width, height) != box {
+		return Sudoku{}, fmt.Errorf("sudoku: invalid box geometry %q", box)
+	}
+	if err := ValidateBox(width, height); err != nil {
+		return Sudoku{}, err
+	}
+	if width*height != s.size {
+		return Sudoku{}, fmt.Errorf("sudoku: %dx%d boxes do not fit a %dx%d grid", width, height, s.size, s.size)
+	}
+	res := NewWithBox(width, height)
+	copy(res.values, s.values)
+	return res, nil
+}
+
+// Meta returns the metadata following the last puzzle returned by Read (empty string if none)
+func (r *Reader) Meta() string {
+	return r.meta
+}
+
+// Line returns the line number of the last puzzle returned by Read
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Writer writes puzzles to a collection file, one puzzle per line in one-line format.
+//
+// Writes are buffered: Flush must be called once all puzzles are written
+type Writer struct {
+	w *bufio.Writer
+}
+
+// NewWriter returns a Writer writing puzzles to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Write writes given puzzle on a new line, with a box geometry suffix if its boxes differ from the default geometry
+// of its size, followed by meta if not empty. Meta must fit on a single line
+func (w *Writer) Write(s Sudoku, meta string) error {
+	if strings.ContainsAny(meta, "\r\n") {
+		return fmt.Errorf("sudoku: metadata %q spans several lines", meta)
+	}
+	line := s.LineString()
+	if width, height := boxDimensions(s.size); width != s.boxWidth || height != s.boxHeight {
+		line += fmt.Sprintf("@%dx%d", s.boxWidth, s.boxHeight)
+	}
+	if meta != "" {
+		line += " " + meta
+	}
+	_, err := w.w.WriteString(line + "\n")
+	return err
+}
+
+// Comment writes given text as a comment line. Text must fit on a single line
+func (w *Writer) Comment(text string) error {
+	if strings.ContainsAny(text, "\r\n") {
+		return fmt.Errorf("sudoku: comment %q spans several lines", text)
+	}
+	_, err := w.w.WriteString("# " + text + "\n")
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
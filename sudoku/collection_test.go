@@ -0,0 +1,142 @@
+package sudoku
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	collection := `# test collection
+..8..7....42..5..............3..68.1........69.........8.13.47.....9.....1....... Hidden Triplets
+9...3.4.....8....9742...6........7.....54.....5..7.81..65..9...........3.31..2...;Very Easy;1.2
+
+   ..5.3.......276....3..8..42.....746.8.13..7........13........7.482..3.1.17.5.....
+..5.3.......276....3..8..42.....746.8.13..7........13...x....7.482..3.1.17.5.....
+1.......2.9.4...5...6...7...5.9.3.......7.......85..4.7.....6...3...9.8...2.....1	Easter Monster	11.9
+`
+	r := NewReader(strings.NewReader(collection))
+	for _, tc := range []struct {
+		line int
+		meta string
+		err  bool
+	}{
+		{2, "Hidden Triplets", false},
+		{3, "Very Easy;1.2", false},
+		{5, "", false},
+		{6, "", true},
+		{7, "Easter Monster\t11.9", false},
+	} {
+		s, err := r.Read()
+		if tc.err {
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Line != tc.line {
+				t.Errorf("expected a ParseError at line %d, got %v", tc.line, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error at line %d: %v", tc.line, err)
+		}
+		if r.Line() != tc.line || r.Meta() != tc.meta || s.Size() != 9 {
+			t.Errorf("got puzzle at line %d with meta %q, expected line %d with meta %q", r.Line(), r.Meta(), tc.line, tc.meta)
+		}
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	if err := w.Comment("generated"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range testPuzzles {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write(s, tc.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewReader(&buf)
+	for _, tc := range testPuzzles {
+		s, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.LineString() != tc.grid || r.Meta() != tc.name {
+			t.Errorf("read back %s %q, expected %s %q", s.LineString(), r.Meta(), tc.grid, tc.name)
+		}
+	}
+}
+
+func TestWriter_Geometry(t *testing.T) {
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	tall := NewWithBox(2, 3)
+	tall.SetValue(1, 0, 0)
+	tall.SetValue(2, 3, 4)
+	wide := NewWithBox(3, 2)
+	wide.SetValue(6, 5, 5)
+	for _, s := range []Sudoku{tall, wide} {
+		if err := w.Write(s, "6x6"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "1.....................2.............@2x3 6x6\n...................................6 6x6\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected collection\n%s", buf.String())
+	}
+
+	r := NewReader(&buf)
+	for _, expected := range []Sudoku{tall, wide} {
+		s, err := r.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, h := s.BoxSize()
+		ew, eh := expected.BoxSize()
+		if s.LineString() != expected.LineString() || w != ew || h != eh || r.Meta() != "6x6" {
+			t.Errorf("read back %s with %dx%d boxes and meta %q, expected %s with %dx%d boxes", s.LineString(), w, h, r.Meta(), expected.LineString(), ew, eh)
+		}
+	}
+}
+
+func TestReader_InvalidGeometry(t *testing.T) {
+	grid := strings.Repeat(".", 36)
+	for _, box := range []string{"3x3", "0x6", "2x", "2x3x1", "axb"} {
+		r := NewReader(strings.NewReader(grid + "@" + box + "\n"))
+		_, err := r.Read()
+		var lerr *LineError
+		if !errors.As(err, &lerr) || lerr.Line != 1 || !strings.HasPrefix(err.Error(), "sudoku: line 1: ") || strings.Count(err.Error(), "sudoku:") != 1 {
+			t.Errorf("box geometry %q: expected a LineError at line 1, got %v", box, err)
+		}
+	}
+}
+
+func TestWriter_Multiline(t *testing.T) {
+	buf := bytes.Buffer{}
+	w := NewWriter(&buf)
+	if err := w.Write(New(4), "name\nrating"); err == nil {
+		t.Error("metadata spanning several lines should be rejected")
+	}
+	if err := w.Comment("first\r\nsecond"); err == nil {
+		t.Error("comment spanning several lines should be rejected")
+	}
+	if err := w.Flush(); err != nil || buf.Len() != 0 {
+		t.Errorf("nothing should be written, got %q", buf.String())
+	}
+}
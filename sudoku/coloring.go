@@ -0,0 +1,224 @@
+package sudoku
+
+import "fmt"
+
+// colorSigns gives the sign displayed after candidates of each color
+var colorSigns = [2]string{"+", "-"}
+
+// colorGraph is a graph of candidates connected by strong links
+type colorGraph struct {
+	nodes []Candidate // candidates, in discovery order
+	links map[Candidate][]Candidate
+}
+
+func (g *colorGraph) addLink(a, b Candidate) {
+	for _, c := range []Candidate{a, b} {
+		if _, found := g.links[c]; !found {
+			g.nodes = append(g.nodes, c)
+		}
+	}
+	g.links[a] = append(g.links[a], b)
+	g.links[b] = append(g.links[b], a)
+}
+
+// strongLinkGraph returns the graph of the strong links between candidates of given values: conjugate pairs of each
+// value, and the two candidates of bivalue cells if cellLinks is true
+func (s *Sudoku) strongLinkGraph(values ValueSet, cellLinks bool) *colorGraph {
+	g := &colorGraph{links: make(map[Candidate][]Candidate)}
+	values.Each(func(v int) {
+		for _, pair := range s.conjugatePairs(v) {
+			g.addLink(Candidate{Cell: pair.cells[0], Value: v}, Candidate{Cell: pair.cells[1], Value: v})
+		}
+	})
+	if cellLinks {
+		grid := s.Candidates()
+		for _, cell := range s.bivalueCells() {
+			vs := grid.Get(cell.Row, cell.Col).GetValues()
+			g.addLink(Candidate{Cell: cell, Value: vs[0]}, Candidate{Cell: cell, Value: vs[1]})
+		}
+	}
+	return g
+}
+
+// colorings returns the connected components of the receiver, each of them colored with two alternating colors
+func (g *colorGraph) colorings() []Coloring {
+	res := []Coloring{}
+	colored := make(map[Candidate]bool)
+	for _, start := range g.nodes {
+		if colored[start] {
+			continue
+		}
+		coloring := Coloring{False: -1}
+		colorOf := map[Candidate]int{start: 0}
+		colored[start] = true
+		queue := []Candidate{start}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			coloring.Colors[colorOf[node]] = append(coloring.Colors[colorOf[node]], node)
+			for _, next := range g.links[node] {
+				if !colored[next] {
+					colored[next] = true
+					colorOf[next] = 1 - colorOf[node]
+					queue = append(queue, next)
+					coloring.Links = append(coloring.Links, [2]Candidate{node, next})
+				}
+			}
+		}
+		res = append(res, coloring)
+	}
+	return res
+}
+
+// colorOf returns the color of given candidate in the receiver, -1 if uncolored
+func (c Coloring) colorOf(candidate Candidate) int {
+	for color, candidates := range c.Colors {
+		for _, cc := range candidates {
+			if cc == candidate {
+				return color
+			}
+		}
+	}
+	return -1
+}
+
+// coloredString returns given candidate followed by the sign of its color
+func coloredString(candidate Candidate, color int) string {
+	return candidate.String() + colorSigns[color]
+}
+
+// falseColor returns the color of given coloring leading to a contradiction (two candidates of this color that can't
+// be both true), with the reason of the contradiction, or -1 if there is none. Medusa rules (contradictions within a
+// cell, and cells emptied by a color) are applied if medusa is true
+func (s *Sudoku) falseColor(coloring Coloring, medusa bool) (int, string) {
+	grid := s.Candidates()
+	for color, candidates := range coloring.Colors {
+		for i, a := range candidates {
+			for _, b := range candidates[i+1:] {
+				switch {
+				case a.Value == b.Value && s.geo.sees(a.Cell, b.Cell):
+					return color, fmt.Sprintf("%s and %s see each other", coloredString(a, color), coloredString(b, color))
+				case medusa && a.Cell == b.Cell:
+					return color, fmt.Sprintf("%s and %s in the same cell", coloredString(a, color), coloredString(b, color))
+				}
+			}
+		}
+	}
+	if !medusa {
+		return -1, ""
+	}
+	// uncolored cell whose candidates all see a candidate of the same value and color
+	for _, option := range grid.Options() {
+		cell := option.cell()
+	Colors:
+		for color, candidates := range coloring.Colors {
+			for _, v := range option.GetValues() {
+				if coloring.colorOf(Candidate{Cell: cell, Value: v}) >= 0 {
+					break Colors
+				}
+				seen := false
+				for _, c := range candidates {
+					seen = seen || c.Value == v && s.geo.sees(c.Cell, cell)
+				}
+				if !seen {
+					continue Colors
+				}
+			}
+			return color, fmt.Sprintf("%s emptied by color %s", option.String(), colorSigns[color])
+		}
+	}
+	return -1, ""
+}
+
+// resolveColoring colors each strong link graph given by graph function, and removes candidates proven false
+func (s *Sudoku) resolveColoring(step *Step, graphs []*colorGraph, medusa bool) {
+	grid := s.Candidates()
+	for _, graph := range graphs {
+		for _, coloring := range graph.colorings() {
+			if len(coloring.Colors[0])+len(coloring.Colors[1]) < 3 { // a single strong link makes no deduction
+				continue
+			}
+			found := false
+			remove := func(candidate Candidate, reason string) {
+				if !grid.Has(candidate.Row, candidate.Col, candidate.Value) {
+					return
+				}
+				option := grid.option(candidate.Cell)
+				s.eliminate(step, candidate.Cell, NewValueSet(candidate.Value))
+				step.Details = append(step.Details, fmt.Sprintf("%s from %s (%s)", NewValueSet(candidate.Value).String(), option.String(), reason))
+				found = true
+			}
+
+			// color wrap: all candidates of a color leading to a contradiction are false
+			if color, reason := s.falseColor(coloring, medusa); color >= 0 {
+				coloring.False = color
+				for _, candidate := range coloring.Colors[color] {
+					remove(candidate, "color wrap: "+reason)
+				}
+				if found {
+					step.Colorings = append(step.Colorings, coloring)
+				}
+				continue
+			}
+
+			// color trap: uncolored candidates seeing both colors are false
+			for _, option := range grid.Options() {
+				cell := option.cell()
+				cellColors := [2][]Candidate{}
+				for _, v := range option.GetValues() {
+					if color := coloring.colorOf(Candidate{Cell: cell, Value: v}); color >= 0 {
+						cellColors[color] = append(cellColors[color], Candidate{Cell: cell, Value: v})
+					}
+				}
+				for _, v := range option.GetValues() {
+					candidate := Candidate{Cell: cell, Value: v}
+					if coloring.colorOf(candidate) >= 0 {
+						continue
+					}
+					// candidate (or cell, for medusa) seeing each color
+					sees := [2]*Candidate{}
+					for color, candidates := range coloring.Colors {
+						for i, c := range candidates {
+							if c.Value == v && s.geo.sees(c.Cell, cell) || medusa && c.Cell == cell {
+								sees[color] = &candidates[i]
+								break
+							}
+						}
+					}
+					if sees[0] != nil && sees[1] != nil {
+						remove(candidate, fmt.Sprintf("color trap: sees %s and %s", coloredString(*sees[0], 0), coloredString(*sees[1], 1)))
+					}
+				}
+			}
+			if found {
+				step.Colorings = append(step.Colorings, coloring)
+			}
+		}
+	}
+}
+
+// ResolveSimpleColoringOptions based on https://www.sudokuwiki.org/Simple_Colouring
+//
+// For each value, cells connected by conjugate pairs are colored with two alternating colors: one of the colors
+// holds the value. When two cells of the same color see each other, this color is false (color wrap). Cells seeing
+// both colors can't hold the value (color trap)
+func (s *Sudoku) ResolveSimpleColoringOptions() Step {
+	step := Step{Technique: "Simple Coloring"}
+	graphs := []*colorGraph{}
+	for v := 1; v <= s.size; v++ {
+		graphs = append(graphs, s.strongLinkGraph(NewValueSet(v), false))
+	}
+	s.resolveColoring(&step, graphs, false)
+	return step
+}
+
+// Resolve3DMedusaOptions based on https://www.sudokuwiki.org/3D_Medusa
+//
+// Coloring extended to all values, candidates being also connected by the strong link between the two candidates
+// of bivalue cells. Besides color wraps and traps, a color is false when two of its candidates are in the same cell,
+// or when it empties an uncolored cell, and uncolored candidates of a cell holding both colors are false
+func (s *Sudoku) Resolve3DMedusaOptions() Step {
+	step := Step{Technique: "3D Medusa"}
+	s.resolveColoring(&step, []*colorGraph{s.strongLinkGraph(fullValueSet(s.size), true)}, true)
+	return step
+}
@@ -0,0 +1,40 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSudoku_ResolveSimpleColoringOptions(t *testing.T) {
+	s := New(9)
+	// conjugate pairs on 1: A1=A5 in column A, A5=E5 in row 5, E5=E2 in column E
+	restrictLine(&s, HouseColumn, 0, 1, 0, 4)
+	restrictLine(&s, HouseRow, 4, 1, 0, 4)
+	restrictLine(&s, HouseColumn, 4, 1, 1, 4)
+
+	step := s.ResolveSimpleColoringOptions()
+	if len(step.Colorings) != 1 {
+		t.Fatalf("expected 1 coloring, got %d (%s)", len(step.Colorings), step.String())
+	}
+	expect := [2][]Candidate{
+		{{Cell{4, 0}, 1}, {Cell{1, 4}, 1}},
+		{{Cell{4, 4}, 1}, {Cell{0, 0}, 1}},
+	}
+	if coloring := step.Colorings[0]; !reflect.DeepEqual(coloring.Colors, expect) || coloring.False != -1 {
+		t.Errorf("got coloring %v, expected colors %v", coloring, expect)
+	}
+	// color trap: B2, C2, D1 and F1 see both A1 and E2
+	if len(step.Eliminations) != 4 {
+		t.Errorf("expected 4 eliminations, got %s", step.String())
+	}
+	for _, cell := range []Cell{{1, 1}, {1, 2}, {0, 3}, {0, 5}} {
+		if s.Candidates().Has(cell.Row, cell.Col, 1) {
+			t.Errorf("expected 1 to be removed from %s, got %s", cell.String(), step.String())
+		}
+	}
+}
+
+func TestSudoku_ResolveColoringOptions_Puzzles(t *testing.T) {
+	checkTechnique(t, StrategySimpleColoring, "......4253...7...96..2.1..31.....6...9..5.........9..2.26.............767.1..3...")
+	checkTechnique(t, StrategyMedusa, "..73.18....6.7..5.5..6..........3..8.9....2..7.2.8........1...4.....2...91....56.")
+}
@@ -0,0 +1,207 @@
+package sudoku
+
+// dlx is a Dancing Links implementation of Knuth's Algorithm X, solving exact cover problems.
+//
+// Nodes are stored in slices and identified by their index: node 0 is the root, nodes 1 to nbColumns are the
+// column headers, other nodes are the 1s of the exact cover matrix rows
+type dlx struct {
+	left, right, up, down []int
+	column                []int // column header of each node
+	rowID                 []int // matrix row of each node
+	count                 []int // number of nodes of each column header
+	solution              []int // matrix rows selected so far
+	solutionFound         func(rows []int) bool
+}
+
+func newDLX(nbColumns int) *dlx {
+	d := &dlx{}
+	for i := 0; i <= nbColumns; i++ {
+		d.left = append(d.left, i-1)
+		d.right = append(d.right, i+1)
+		d.up = append(d.up, i)
+		d.down = append(d.down, i)
+		d.column = append(d.column, i)
+		d.rowID = append(d.rowID, -1)
+		d.count = append(d.count, 0)
+	}
+	d.left[0] = nbColumns
+	d.right[nbColumns] = 0
+	return d
+}
+
+// addRow adds a matrix row, with 1s in given columns (numbered from 0), and returns its first node
+func (d *dlx) addRow(id int, columns ...int) int {
+	first := len(d.left)
+	for i, col := range columns {
+		header := col + 1
+		node := len(d.left)
+		d.left = append(d.left, node-1)
+		d.right = append(d.right, node+1)
+		if i == 0 {
+			d.left[node] = first + len(columns) - 1
+		}
+		if i == len(columns)-1 {
+			d.right[node] = first
+		}
+		d.up = append(d.up, d.up[header])
+		d.down = append(d.down, header)
+		d.down[d.up[header]] = node
+		d.up[header] = node
+		d.column = append(d.column, header)
+		d.rowID = append(d.rowID, id)
+		d.count[header]++
+	}
+	return first
+}
+
+func (d *dlx) cover(header int) {
+	d.right[d.left[header]] = d.right[header]
+	d.left[d.right[header]] = d.left[header]
+	for i := d.down[header]; i != header; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.count[d.column[j]]--
+		}
+	}
+}
+
+func (d *dlx) uncover(header int) {
+	for i := d.up[header]; i != header; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.count[d.column[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[header]] = header
+	d.left[d.right[header]] = header
+}
+
+// selectRow covers all columns of the row containing given node, as if it was part of the solution
+func (d *dlx) selectRow(node int) {
+	d.cover(d.column[node])
+	for j := d.right[node]; j != node; j = d.right[j] {
+		d.cover(d.column[j])
+	}
+}
+
+// search explores all exact covers, calling solutionFound for each of them.
+// Search stops as soon as solutionFound returns false, search then returns false
+func (d *dlx) search() bool {
+	if d.right[0] == 0 {
+		return d.solutionFound(d.solution)
+	}
+	// choose the column with the fewest nodes
+	header, best := 0, -1
+	for c := d.right[0]; c != 0; c = d.right[c] {
+		if best < 0 || d.count[c] < best {
+			header, best = c, d.count[c]
+			if best <= 1 {
+				break
+			}
+		}
+	}
+	if best == 0 {
+		return true // dead end, go on with other branches
+	}
+
+	d.cover(header)
+	goOn := true
+	for i := d.down[header]; goOn && i != header; i = d.down[i] {
+		d.solution = append(d.solution, d.rowID[i])
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.cover(d.column[j])
+		}
+		goOn = d.search()
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.uncover(d.column[j])
+		}
+		d.solution = d.solution[:len(d.solution)-1]
+	}
+	d.uncover(header)
+	return goOn
+}
+
+// newSudokuDLX returns the exact cover problem of given sudoku: one matrix row per possible (cell, value)
+// placement, one column per constraint (each cell, and each value in each row, column and box, used once).
+// Sudoku values are selected in advance. ok is false if sudoku values conflict with each other
+func newSudokuDLX(s Sudoku) (d *dlx, ok bool) {
+	bt, ok := newBacktracker(s)
+	if !ok {
+		return nil, false
+	}
+	n := s.size
+	d = newDLX(4 * n * n)
+	givens := []int{}
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			b := bt.box(r, c)
+			for v := 1; v <= n; v++ {
+				given := s.getValue(r, c)
+				if given != valueUndef && given != v || given == valueUndef && !bt.candidates(r, c).Has(v) {
+					continue
+				}
+				node := d.addRow((r*n+c)*n+v-1, r*n+c, n*n+r*n+v-1, 2*n*n+c*n+v-1, 3*n*n+b*n+v-1)
+				if given != valueUndef {
+					givens = append(givens, node)
+				}
+			}
+		}
+	}
+	for _, node := range givens {
+		d.selectRow(node)
+	}
+	return d, true
+}
+
+// dlxSolution returns a new Sudoku holding given sudoku values completed with given exact cover rows
+func dlxSolution(s Sudoku, rows []int) Sudoku {
+	solution := NewWithBox(s.boxWidth, s.boxHeight)
+	copy(solution.values, s.values)
+	for _, id := range rows {
+		cell, value := id/s.size, id%s.size+1
+		solution.values[cell] = value
+	}
+	return solution
+}
+
+// SolveDLX solves the receiver using the Dancing Links exact cover search, and returns true if a solution was
+// found. If the receiver has several solutions, the first one found is set
+func (s *Sudoku) SolveDLX() bool {
+	d, ok := newSudokuDLX(*s)
+	if !ok {
+		return false
+	}
+	found := false
+	d.solutionFound = func(rows []int) bool {
+		solution := dlxSolution(*s, rows)
+		for i, value := range solution.values {
+			if s.values[i] == valueUndef {
+				s.SetValue(value, i/s.size, i%s.size)
+			}
+		}
+		found = true
+		return false
+	}
+	d.search()
+	return found
+}
+
+// CountSolutionsDLX returns the number of solutions of the receiver, using the Dancing Links exact cover search.
+// It counts up to limit solutions (limit <= 0 counts all solutions).
+//
+// Receiver is not modified. 0 is returned if receiver values conflict with each other
+func (s Sudoku) CountSolutionsDLX(limit int) int {
+	d, ok := newSudokuDLX(s)
+	if !ok {
+		return 0
+	}
+	nb := 0
+	d.solutionFound = func(rows []int) bool {
+		nb++
+		return limit <= 0 || nb < limit
+	}
+	d.search()
+	return nb
+}
@@ -0,0 +1,58 @@
+package sudoku
+
+import "testing"
+
+func TestSudoku_SolveDLX(t *testing.T) {
+	for _, tc := range testPuzzles {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nb, expect := s.CountSolutionsDLX(100), s.CountSolutions(100); nb != expect {
+			t.Errorf("%s: CountSolutionsDLX returned %d, expected %d", tc.name, nb, expect)
+		}
+		if _, completed := (Solver{Engine: EngineDLX}).Solve(&s); !completed {
+			t.Errorf("%s: sudoku should be completed", tc.name)
+		}
+		checkSolved(t, s)
+	}
+
+	// 16x16 grid built from a valid pattern with some cells removed
+	s16 := New(16)
+	for r := 0; r < 16; r++ {
+		for c := 0; c < 16; c++ {
+			if (r*7+c*3)%3 == 0 {
+				continue
+			}
+			s16.SetValue((4*(r%4)+r/4+c)%16+1, r, c)
+		}
+	}
+	if !s16.SolveDLX() {
+		t.Fatalf("16x16 sudoku should be solved")
+	}
+	checkSolved(t, s16)
+
+	if New(4).CountSolutionsDLX(0) != 288 {
+		t.Errorf("empty 4x4 grid should have 288 solutions")
+	}
+	conflicting, _ := Parse("11" + testPuzzles[1].grid[2:])
+	if conflicting.SolveDLX() || conflicting.CountSolutionsDLX(0) != 0 {
+		t.Errorf("conflicting sudoku should have no solution")
+	}
+}
+
+func BenchmarkSudoku_SolveDLX(b *testing.B) {
+	puzzle, _ := Parse(testPuzzles[5].grid)
+	for i := 0; i < b.N; i++ {
+		s := puzzle.Clone()
+		s.SolveDLX()
+	}
+}
+
+func BenchmarkSolver_Solve(b *testing.B) {
+	puzzle, _ := Parse(testPuzzles[5].grid)
+	for i := 0; i < b.N; i++ {
+		s := puzzle.Clone()
+		Solver{}.Solve(&s)
+	}
+}
@@ -0,0 +1,241 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// fishNames gives the name of basic fish patterns, by size
+var fishNames = map[int]string{2: "X-Wing", 3: "Swordfish", 4: "Jellyfish"}
+
+// lineCell returns the cell at position pos of the line of given kind (row or column) and index
+func lineCell(kind HouseKind, index, pos int) Cell {
+	if kind == HouseRow {
+		return Cell{Row: index, Col: pos}
+	}
+	return Cell{Row: pos, Col: index}
+}
+
+// crossKind returns the line kind crossing lines of given kind (columns for rows, rows for columns)
+func crossKind(kind HouseKind) HouseKind {
+	if kind == HouseRow {
+		return HouseColumn
+	}
+	return HouseRow
+}
+
+// linePositions returns, for each line of given kind, the mask of positions within the line having value as candidate
+func (s *Sudoku) linePositions(kind HouseKind, value int) []uint32 {
+	grid := s.Candidates()
+	res := make([]uint32, s.size)
+	for index := 0; index < s.size; index++ {
+		for pos := 0; pos < s.size; pos++ {
+			if cell := lineCell(kind, index, pos); grid.Has(cell.Row, cell.Col, value) {
+				res[index] |= 1 << pos
+			}
+		}
+	}
+	return res
+}
+
+// maskHouses returns the lines of given kind whose index belong to mask
+func maskHouses(kind HouseKind, mask uint32) []House {
+	res := []House{}
+	for cur := mask; cur != 0; cur &= cur - 1 {
+		res = append(res, House{Kind: kind, Index: bits.TrailingZeros32(cur)})
+	}
+	return res
+}
+
+// resolveFish searches basic fish of given size for each value: when the candidates of a value in size base lines
+// (rows, or columns) all lie in size cover lines crossing them, the value must be set at base and cover lines
+// intersections, so it can be removed from the other cells of the cover lines
+func (s *Sudoku) resolveFish(step *Step, size int) {
+	grid := s.Candidates()
+	for v := 1; v <= s.size; v++ {
+		for _, baseKind := range []HouseKind{HouseRow, HouseColumn} {
+			positions := s.linePositions(baseKind, v)
+			lines, masks := []int{}, []uint32{}
+			for index, mask := range positions {
+				if nb := bits.OnesCount32(mask); nb >= 2 && nb <= size {
+					lines = append(lines, index)
+					masks = append(masks, mask)
+				}
+			}
+			forEachSubset(masks, size, func(chosen []int, cover uint32) {
+				var base uint32
+				for _, i := range chosen {
+					base |= 1 << lines[i]
+				}
+				reason := fmt.Sprintf("base %s / cover %s", housesString(maskHouses(baseKind, base)), housesString(maskHouses(crossKind(baseKind), cover)))
+				for _, coverLine := range maskHouses(crossKind(baseKind), cover) {
+					for index := 0; index < s.size; index++ {
+						cell := lineCell(baseKind, index, coverLine.Index)
+						if base&(1<<index) != 0 || !grid.Has(cell.Row, cell.Col, v) {
+							continue
+						}
+						option := grid.option(cell)
+						s.eliminate(step, cell, NewValueSet(v))
+						step.Details = append(step.Details, fmt.Sprintf("%s from %s in %s (%s)", NewValueSet(v).String(), option.String(), coverLine.String(), reason))
+					}
+				}
+			})
+		}
+	}
+}
+
+// forEachCombination calls fn for each combination of size indexes among 0..n-1, in ascending order
+func forEachCombination(n, size int, fn func(chosen []int)) {
+	chosen := make([]int, 0, size)
+	var search func(start int)
+	search = func(start int) {
+		if len(chosen) == size {
+			fn(chosen)
+			return
+		}
+		for i := start; i <= n-(size-len(chosen)); i++ {
+			chosen = append(chosen, i)
+			search(i + 1)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	search(0)
+}
+
+// resolveFinnedFish searches finned fish of given size for each value: a fish whose base lines also hold candidates
+// outside the cover lines (the fins), all fins being in the same box. Either a fin holds the value, or the fish
+// does: in both cases the value can be removed from the cover lines cells, outside base lines, seeing all fins.
+//
+// A sashimi fish is a finned fish with a base line having a single candidate left in cover lines once fins are
+// removed (the fish would be degenerate without its fins)
+func (s *Sudoku) resolveFinnedFish(step *Step, size int) {
+	for v := 1; v <= s.size; v++ {
+		for _, baseKind := range []HouseKind{HouseRow, HouseColumn} {
+			positions := s.linePositions(baseKind, v)
+			lines := []int{}
+			for index, mask := range positions {
+				if bits.OnesCount32(mask) >= 2 {
+					lines = append(lines, index)
+				}
+			}
+			forEachCombination(len(lines), size, func(chosenLines []int) {
+				var base uint32
+				for _, i := range chosenLines {
+					base |= 1 << lines[i]
+				}
+				// fins being in a single box, base candidates outside of this box must all be in cover lines
+				for _, finBox := range s.housesOf(HouseBox) {
+					var outside, inside uint32
+					for _, i := range chosenLines {
+						for cur := positions[lines[i]]; cur != 0; cur &= cur - 1 {
+							pos := bits.TrailingZeros32(cur)
+							if cell := lineCell(baseKind, lines[i], pos); s.geo.house(HouseBox, cell.Row, cell.Col) == finBox {
+								inside |= 1 << pos
+							} else {
+								outside |= 1 << pos
+							}
+						}
+					}
+					extra := maskHouses(crossKind(baseKind), inside&^outside)
+					missing := size - bits.OnesCount32(outside)
+					if inside == 0 || missing < 0 || missing > len(extra) {
+						continue
+					}
+					forEachCombination(len(extra), missing, func(chosenCovers []int) {
+						cover := outside
+						for _, i := range chosenCovers {
+							cover |= 1 << extra[i].Index
+						}
+						s.eliminateFinnedFish(step, v, baseKind, base, cover, positions, finBox)
+					})
+				}
+			})
+		}
+	}
+}
+
+// eliminateFinnedFish removes value from the cells of finBox located in cover lines outside base lines, if base and
+// cover lines make a finned fish with fins in finBox
+func (s *Sudoku) eliminateFinnedFish(step *Step, value int, baseKind HouseKind, base, cover uint32, positions []uint32, finBox House) {
+	grid := s.Candidates()
+	coverKind := crossKind(baseKind)
+	fins, sashimi := []Cell{}, false
+	for _, line := range maskHouses(baseKind, base) {
+		inCover := positions[line.Index] & cover
+		if inCover == 0 {
+			return // base line fully made of fins
+		}
+		sashimi = sashimi || bits.OnesCount32(inCover) == 1
+		for cur := positions[line.Index] &^ cover; cur != 0; cur &= cur - 1 {
+			fins = append(fins, lineCell(baseKind, line.Index, bits.TrailingZeros32(cur)))
+		}
+	}
+	if len(fins) == 0 { // basic fish
+		return
+	}
+
+	kind := "finned"
+	if sashimi {
+		kind = "sashimi"
+	}
+	finNames := make([]string, len(fins))
+	for i, fin := range fins {
+		finNames[i] = fin.String()
+	}
+	reason := fmt.Sprintf("%s base %s / cover %s / fins %s", kind, housesString(maskHouses(baseKind, base)), housesString(maskHouses(coverKind, cover)), strings.Join(finNames, ", "))
+	for _, cell := range s.HouseCells(finBox) {
+		baseIndex, coverIndex := cell.Row, cell.Col
+		if baseKind == HouseColumn {
+			baseIndex, coverIndex = cell.Col, cell.Row
+		}
+		if base&(1<<baseIndex) != 0 || cover&(1<<coverIndex) == 0 || !grid.Has(cell.Row, cell.Col, value) {
+			continue
+		}
+		option := grid.option(cell)
+		s.eliminate(step, cell, NewValueSet(value))
+		step.Details = append(step.Details, fmt.Sprintf("%s from %s in %s (%s)", NewValueSet(value).String(), option.String(), House{Kind: coverKind, Index: coverIndex}.String(), reason))
+	}
+}
+
+// ResolveXWingOptions based on https://www.sudokuwiki.org/X_Wing_Strategy
+func (s *Sudoku) ResolveXWingOptions() Step {
+	step := Step{Technique: fishNames[2]}
+	s.resolveFish(&step, 2)
+	return step
+}
+
+// ResolveSwordfishOptions based on https://www.sudokuwiki.org/Sword_Fish_Strategy
+func (s *Sudoku) ResolveSwordfishOptions() Step {
+	step := Step{Technique: fishNames[3]}
+	s.resolveFish(&step, 3)
+	return step
+}
+
+// ResolveJellyfishOptions based on https://www.sudokuwiki.org/Jelly_Fish_Strategy
+func (s *Sudoku) ResolveJellyfishOptions() Step {
+	step := Step{Technique: fishNames[4]}
+	s.resolveFish(&step, 4)
+	return step
+}
+
+// ResolveFinnedXWingOptions based on https://www.sudokuwiki.org/Finned_X_Wing (sashimi X-Wings included)
+func (s *Sudoku) ResolveFinnedXWingOptions() Step {
+	step := Step{Technique: "Finned " + fishNames[2]}
+	s.resolveFinnedFish(&step, 2)
+	return step
+}
+
+// ResolveFinnedSwordfishOptions based on https://www.sudokuwiki.org/Finned_Swordfish (sashimi Swordfishes included)
+func (s *Sudoku) ResolveFinnedSwordfishOptions() Step {
+	step := Step{Technique: "Finned " + fishNames[3]}
+	s.resolveFinnedFish(&step, 3)
+	return step
+}
+
+// ResolveFinnedJellyfishOptions based on https://www.sudokuwiki.org/Finned_Swordfish (sashimi Jellyfishes included)
+func (s *Sudoku) ResolveFinnedJellyfishOptions() Step {
+	step := Step{Technique: "Finned " + fishNames[4]}
+	s.resolveFinnedFish(&step, 4)
+	return step
+}
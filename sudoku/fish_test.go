@@ -0,0 +1,102 @@
+package sudoku
+
+import (
+	"strings"
+	"testing"
+)
+
+// restrictLine removes value from the candidates of the line of given kind and index, except at given positions
+func restrictLine(s *Sudoku, kind HouseKind, index, value int, positions ...int) {
+	keep := make(map[int]bool)
+	for _, pos := range positions {
+		keep[pos] = true
+	}
+	for pos := 0; pos < s.size; pos++ {
+		if !keep[pos] {
+			cell := lineCell(kind, index, pos)
+			s.Candidates().Eliminate(cell.Row, cell.Col, NewValueSet(value))
+		}
+	}
+}
+
+func TestSudoku_ResolveXWingOptions(t *testing.T) {
+	s := New(9)
+	// 5 only possible in columns A and E of rows 2 and 7
+	restrictLine(&s, HouseRow, 1, 5, 0, 4)
+	restrictLine(&s, HouseRow, 6, 5, 0, 4)
+
+	step := s.ResolveXWingOptions()
+	if len(step.Eliminations) != 14 {
+		t.Fatalf("expected 14 eliminations, got %s", step.String())
+	}
+	for _, el := range step.Eliminations {
+		if el.Col != 0 && el.Col != 4 || el.Row == 1 || el.Row == 6 {
+			t.Errorf("unexpected elimination %s", el.String())
+		}
+	}
+	if !strings.Contains(step.Details[0], "(base rows 2, 7 / cover columns A, E)") {
+		t.Errorf("base and cover sets not reported: %s", step.Details[0])
+	}
+}
+
+func TestSudoku_ResolveSwordfishOptions(t *testing.T) {
+	s := New(9)
+	// 7 only possible in rows 1, 5 and 9 of columns B, D and H, with two candidates per column
+	restrictLine(&s, HouseColumn, 1, 7, 0, 4)
+	restrictLine(&s, HouseColumn, 3, 7, 4, 8)
+	restrictLine(&s, HouseColumn, 7, 7, 0, 8)
+
+	step := s.ResolveSwordfishOptions()
+	if !step.Found() {
+		t.Fatal("swordfish not found")
+	}
+	for _, el := range step.Eliminations {
+		if el.Row != 0 && el.Row != 4 && el.Row != 8 {
+			t.Errorf("unexpected elimination %s", el.String())
+		}
+	}
+	if !strings.Contains(step.Details[0], "(base columns B, D, H / cover rows 1, 5, 9)") {
+		t.Errorf("base and cover sets not reported: %s", step.Details[0])
+	}
+}
+
+func TestSudoku_ResolveXWingOptions_Corpus(t *testing.T) {
+	checkTechnique(t, StrategyXWing, uniqueTestGrids(t)...)
+}
+
+func TestSudoku_ResolveFinnedXWingOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		positions []int // positions of 3 in row 7
+		expect    []string
+	}{
+		{"finned", []int{0, 4, 5}, []string{"E8-[3]", "E9-[3]"}},
+		// sashimi both ways: fin F7 for cover columns A and E, and fin E2 for cover columns A and F
+		{"sashimi", []int{0, 5}, []string{"F1-[3]", "F3-[3]", "E8-[3]", "E9-[3]"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New(9)
+			// 3 only possible in columns A and E of row 2 and in row 7, with a fin in F7
+			restrictLine(&s, HouseRow, 1, 3, 0, 4)
+			restrictLine(&s, HouseRow, 6, 3, tc.positions...)
+
+			step := s.ResolveFinnedXWingOptions()
+			if len(step.Eliminations) != len(tc.expect) {
+				t.Fatalf("expected eliminations %v, got %s", tc.expect, step.String())
+			}
+			for i, el := range step.Eliminations {
+				if el.String() != tc.expect[i] {
+					t.Errorf("expected elimination %s, got %s", tc.expect[i], el.String())
+				}
+			}
+			reason := "(" + tc.name + " base rows 2, 7 / cover columns A, E / fins F7)"
+			if last := step.Details[len(step.Details)-1]; !strings.Contains(last, reason) {
+				t.Errorf("expected %q in %q", reason, last)
+			}
+		})
+	}
+}
+
+func TestSudoku_ResolveFinnedXWingOptions_Corpus(t *testing.T) {
+	checkTechnique(t, StrategyFinnedXWing, uniqueTestGrids(t)...)
+}
@@ -0,0 +1,448 @@
+package sudoku
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxForcingDepth is the maximum number of propagation rounds following a forcing chain assumption
+const maxForcingDepth = 20
+
+// forcingNode is a placement made while propagating an assumption, with the nodes it follows from
+type forcingNode struct {
+	placement Placement
+	reason    string // single giving the placement, empty for the assumption
+	parents   []int
+}
+
+// forcingNet holds the consequences of an assumption (a value placed in a cell), propagated with naked and hidden
+// singles on a copy of the candidates. Each placement records the placements which removed the candidates it
+// follows from, making a net rather than a simple chain
+type forcingNet struct {
+	s         *Sudoku
+	base      []ValueSet // candidates before the assumption
+	cells     []ValueSet // candidates after propagation, empty for placed cells
+	values    []int      // values placed during propagation
+	removedBy []int      // index of the node which removed each candidate (see Sudoku.candidateIndex), -1 if none
+	nodes     []forcingNode
+
+	// number of candidate cells of each value in each house, and whether the value is placed in the house (indexed
+	// by house index * (size+1) + value)
+	counts []int
+	solved []bool
+
+	contradiction        string // description of the contradiction reached, if any
+	contradictionParents []int
+}
+
+// propagate returns the net of the consequences of placing value in cell, following singles for at most
+// maxForcingDepth rounds, or until a contradiction is reached
+func (s *Sudoku) propagate(assumption Placement) *forcingNet {
+	grid := s.Candidates()
+	net := &forcingNet{
+		s:         s,
+		base:      append([]ValueSet{}, grid.cells...),
+		cells:     append([]ValueSet{}, grid.cells...),
+		values:    make([]int, len(grid.cells)),
+		removedBy: make([]int, len(grid.cells)*s.size),
+		counts:    make([]int, len(s.Houses())*(s.size+1)),
+		solved:    make([]bool, len(s.Houses())*(s.size+1)),
+	}
+	for i := range net.removedBy {
+		net.removedBy[i] = -1
+	}
+	for i, candidates := range net.cells {
+		if s.values[i] != valueUndef {
+			net.updateHouses(i, s.values[i], 0, true)
+		}
+		candidates.Each(func(v int) {
+			net.updateHouses(i, v, 1, false)
+		})
+	}
+	net.place(forcingNode{placement: assumption})
+	for depth := 0; depth < maxForcingDepth && net.contradiction == ""; depth++ {
+		singles := net.singles()
+		if len(singles) == 0 {
+			break
+		}
+		for _, node := range singles {
+			if net.contradiction != "" {
+				break
+			}
+			net.place(node)
+		}
+		if net.contradiction == "" {
+			net.checkHouses()
+		}
+	}
+	return net
+}
+
+// removal returns the index of the node which removed given candidate, -1 if it wasn't removed
+func (net *forcingNet) removal(c Candidate) int {
+	return net.removedBy[net.s.candidateIndex(c)]
+}
+
+// updateHouses adds delta to the candidate counts of value in the houses of cell i, marking value as placed if solved
+func (net *forcingNet) updateHouses(i, value, delta int, solved bool) {
+	for kind, index := range net.s.geo.cellHouses[i] {
+		k := (kind*net.s.size+index)*(net.s.size+1) + value
+		net.counts[k] += delta
+		net.solved[k] = net.solved[k] || solved
+	}
+}
+
+// remove removes given candidate, recording node as its cause
+func (net *forcingNet) remove(c Candidate, node int) {
+	i := c.Col + c.Row*net.s.size
+	if !net.cells[i].Has(c.Value) {
+		return
+	}
+	net.cells[i].RemoveValue(c.Value)
+	net.updateHouses(i, c.Value, -1, false)
+	net.removedBy[net.s.candidateIndex(c)] = node
+}
+
+// place adds node to the net, and removes the candidates its placement excludes
+func (net *forcingNet) place(node forcingNode) {
+	p := node.placement
+	i := p.Col + p.Row*net.s.size
+	if net.values[i] == p.Value {
+		return // found by another single in the same round
+	}
+	if !net.cells[i].Has(p.Value) {
+		net.contradiction = fmt.Sprintf("%s and %s", p.String(), eliminationString(Candidate{Cell: p.Cell, Value: p.Value}))
+		net.contradictionParents = append(append([]int{}, node.parents...), net.removal(Candidate{Cell: p.Cell, Value: p.Value}))
+		return
+	}
+	index := len(net.nodes)
+	net.nodes = append(net.nodes, node)
+	net.cells[i].Each(func(v int) {
+		if v != p.Value {
+			net.remove(Candidate{Cell: p.Cell, Value: v}, index)
+		}
+	})
+	net.cells[i] = NewValueSet()
+	net.updateHouses(i, p.Value, -1, true)
+	net.values[i] = p.Value
+	for _, peer := range net.s.Peers(p.Row, p.Col) {
+		net.remove(Candidate{Cell: peer, Value: p.Value}, index)
+		j := peer.Col + peer.Row*net.s.size
+		if net.values[j] == 0 && net.s.values[j] == 0 && net.cells[j] == 0 && net.contradiction == "" {
+			net.contradiction = fmt.Sprintf("%s has no candidate", peer.String())
+			net.contradictionParents = net.cellRemovals(peer, 0)
+		}
+	}
+}
+
+// cellRemovals returns the nodes which removed the candidates of cell, except value
+func (net *forcingNet) cellRemovals(cell Cell, value int) []int {
+	res := []int{}
+	net.base[cell.Col+cell.Row*net.s.size].Each(func(v int) {
+		if v != value {
+			res = append(res, net.removal(Candidate{Cell: cell, Value: v}))
+		}
+	})
+	return res
+}
+
+// houseRemovals returns the nodes which removed value from the cells of house, except cell
+func (net *forcingNet) houseRemovals(house House, value int, cell Cell) []int {
+	res := []int{}
+	for _, other := range net.s.HouseCells(house) {
+		if other != cell && net.base[other.Col+other.Row*net.s.size].Has(value) {
+			res = append(res, net.removal(Candidate{Cell: other, Value: value}))
+		}
+	}
+	return res
+}
+
+// houseState returns, for value in house, whether it was placed, and the number of cells still having it as
+// candidate
+func (net *forcingNet) houseState(house House, value int) (bool, int) {
+	k := (int(house.Kind)*net.s.size+house.Index)*(net.s.size+1) + value
+	return net.solved[k], net.counts[k]
+}
+
+// candidateCell returns the first cell of house having value as candidate
+func (net *forcingNet) candidateCell(house House, value int) Cell {
+	for _, cell := range net.s.HouseCells(house) {
+		if net.cells[cell.Col+cell.Row*net.s.size].Has(value) {
+			return cell
+		}
+	}
+	return Cell{Row: -1, Col: -1}
+}
+
+// singles returns the naked and hidden singles of the current net candidates
+func (net *forcingNet) singles() []forcingNode {
+	res := []forcingNode{}
+	for i, candidates := range net.cells {
+		if candidates.Length() == 1 {
+			cell := Cell{Row: i / net.s.size, Col: i % net.s.size}
+			value := candidates.First()
+			res = append(res, forcingNode{
+				placement: Placement{Cell: cell, Value: value},
+				reason:    "naked single",
+				parents:   net.cellRemovals(cell, value),
+			})
+		}
+	}
+	for _, house := range net.s.Houses() {
+		for v := 1; v <= net.s.size; v++ {
+			if placed, count := net.houseState(house, v); placed || count != 1 {
+				continue
+			}
+			cell := net.candidateCell(house, v)
+			res = append(res, forcingNode{
+				placement: Placement{Cell: cell, Value: v},
+				reason:    "hidden single in " + house.String(),
+				parents:   net.houseRemovals(house, v, cell),
+			})
+		}
+	}
+	return res
+}
+
+// checkHouses looks for a value having no more candidate cell in a house
+func (net *forcingNet) checkHouses() {
+	for _, house := range net.s.Houses() {
+		for v := 1; v <= net.s.size; v++ {
+			if placed, count := net.houseState(house, v); !placed && count == 0 {
+				net.contradiction = fmt.Sprintf("no cell for %s in %s", NewValueSet(v).String(), house.String())
+				net.contradictionParents = net.houseRemovals(house, v, Cell{Row: -1, Col: -1})
+				return
+			}
+		}
+	}
+}
+
+// eliminationString returns the elimination of given candidate ("A1-[5]")
+func eliminationString(c Candidate) string {
+	return Elimination{Cell: c.Cell, Values: []int{c.Value}}.String()
+}
+
+// proof returns the placements leading from the assumption to given nodes, in propagation order
+// ("A1=5 -> B3=2 (naked single) -> D3=7 (hidden single in row 3)")
+func (net *forcingNet) proof(nodes ...int) []string {
+	used := make(map[int]bool)
+	var visit func(n int)
+	visit = func(n int) {
+		if n < 0 || used[n] {
+			return
+		}
+		used[n] = true
+		for _, parent := range net.nodes[n].parents {
+			visit(parent)
+		}
+	}
+	for _, n := range nodes {
+		visit(n)
+	}
+	indexes := []int{}
+	for n := range used {
+		indexes = append(indexes, n)
+	}
+	sort.Ints(indexes)
+	res := []string{}
+	for _, n := range indexes {
+		node := net.nodes[n]
+		if node.reason == "" {
+			res = append(res, node.placement.String())
+		} else {
+			res = append(res, fmt.Sprintf("%s (%s)", node.placement.String(), node.reason))
+		}
+	}
+	return res
+}
+
+// contradictionProof returns the proof of the contradiction reached by the net
+func (net *forcingNet) contradictionProof() []string {
+	return append(net.proof(net.contradictionParents...), net.contradiction)
+}
+
+// forcingConclusion is a placement or an elimination following from all branches of a forcing chain, with the
+// proof of each branch
+type forcingConclusion struct {
+	candidate Candidate
+	placed    bool
+	proofs    [][]string
+}
+
+// length returns the number of proof steps of the receiver
+func (fc forcingConclusion) length() int {
+	res := 0
+	for _, proof := range fc.proofs {
+		res += len(proof)
+	}
+	return res
+}
+
+// commonConclusions returns the placements and eliminations made by all given nets, not already made on the grid
+func (s *Sudoku) commonConclusions(nets []*forcingNet) []forcingConclusion {
+	res := []forcingConclusion{}
+	first := nets[0]
+	for i, base := range first.base {
+		cell := Cell{Row: i / s.size, Col: i % s.size}
+		if base == 0 {
+			continue
+		}
+		// placement in all branches
+		if v := first.values[i]; v != 0 {
+			fc := forcingConclusion{candidate: Candidate{Cell: cell, Value: v}, placed: true}
+			for _, net := range nets {
+				if net.values[i] != v {
+					fc.proofs = nil
+					break
+				}
+				fc.proofs = append(fc.proofs, net.proof(net.nodeOf(cell)))
+			}
+			if fc.proofs != nil {
+				res = append(res, fc)
+				continue
+			}
+		}
+		// eliminations in all branches
+	Values:
+		for _, v := range base.GetValues() {
+			fc := forcingConclusion{candidate: Candidate{Cell: cell, Value: v}}
+			for _, net := range nets {
+				n := net.removal(fc.candidate)
+				if n < 0 {
+					continue Values
+				}
+				fc.proofs = append(fc.proofs, append(net.proof(n), eliminationString(fc.candidate)))
+			}
+			res = append(res, fc)
+		}
+	}
+	return res
+}
+
+// nodeOf returns the index of the node placing a value in given cell, -1 if none
+func (net *forcingNet) nodeOf(cell Cell) int {
+	for i, node := range net.nodes {
+		if node.placement.Cell == cell {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyForcing applies given conclusion, described with given forcing chain name
+func (s *Sudoku) applyForcing(step *Step, fc forcingConclusion, name string) {
+	grid := s.Candidates()
+	option := grid.option(fc.candidate.Cell)
+	branches := []string{}
+	for _, proof := range fc.proofs {
+		branches = append(branches, strings.Join(proof, " -> "))
+	}
+	reason := fmt.Sprintf("%s: %s", name, strings.Join(branches, " / "))
+	values := NewValueSet(fc.candidate.Value)
+	if fc.placed {
+		if s.place(step, fc.candidate.Cell, fc.candidate.Value) {
+			step.Details = append(step.Details, fmt.Sprintf("%s in %s (%s)", values.String(), option.String(), reason))
+		}
+		return
+	}
+	s.removeCandidates(step, fc.candidate.Cell, values, reason)
+}
+
+// shortestConclusion returns the conclusion of given forcing chains with the shortest proof
+func shortestConclusion(conclusions []forcingConclusion) (forcingConclusion, bool) {
+	var best forcingConclusion
+	found := false
+	for _, fc := range conclusions {
+		if !found || fc.length() < best.length() {
+			best, found = fc, true
+		}
+	}
+	return best, found
+}
+
+// forcingBranches returns the nets of given assumptions, or false if one of them leads to a contradiction (the
+// assumption is then removed by a nishio net)
+func (s *Sudoku) forcingBranches(assumptions []Placement) ([]*forcingNet, bool) {
+	nets := []*forcingNet{}
+	for _, p := range assumptions {
+		net := s.propagate(p)
+		if net.contradiction != "" {
+			return nil, false
+		}
+		nets = append(nets, net)
+	}
+	return nets, true
+}
+
+// ResolveNishioOptions based on https://www.sudokuwiki.org/Nishio_Forcing_Chains
+//
+// A candidate whose placement leads, following singles, to a contradiction (a cell without candidate, or a value
+// without cell in a house) is false
+func (s *Sudoku) ResolveNishioOptions() Step {
+	step := Step{Technique: "Nishio Forcing Net"}
+	conclusions := []forcingConclusion{}
+	for _, option := range s.Candidates().Options() {
+		for _, v := range option.GetValues() {
+			c := Candidate{Cell: option.cell(), Value: v}
+			if net := s.propagate(Placement{Cell: c.Cell, Value: v}); net.contradiction != "" {
+				conclusions = append(conclusions, forcingConclusion{candidate: c, proofs: [][]string{net.contradictionProof()}})
+			}
+		}
+	}
+	// all contradictions are found on the same candidates: they can be applied together
+	for _, fc := range conclusions {
+		s.applyForcing(&step, fc, "contradiction net")
+	}
+	return step
+}
+
+// ResolveCellForcingChainOptions based on https://www.sudokuwiki.org/Cell_Forcing_Chains
+//
+// Each candidate of a cell is placed in turn, following singles: a placement or an elimination made whatever the
+// cell value holds. Only the conclusion with the shortest proof is applied
+func (s *Sudoku) ResolveCellForcingChainOptions() Step {
+	step := Step{Technique: "Cell Forcing Chain"}
+	conclusions := []forcingConclusion{}
+	for _, option := range s.Candidates().Options() {
+		assumptions := []Placement{}
+		for _, v := range option.GetValues() {
+			assumptions = append(assumptions, Placement{Cell: option.cell(), Value: v})
+		}
+		if nets, ok := s.forcingBranches(assumptions); ok {
+			conclusions = append(conclusions, s.commonConclusions(nets)...)
+		}
+	}
+	if fc, found := shortestConclusion(conclusions); found {
+		s.applyForcing(&step, fc, "cell forcing chain")
+	}
+	return step
+}
+
+// ResolveUnitForcingChainOptions based on https://www.sudokuwiki.org/Unit_Forcing_Chains
+//
+// A value is placed in turn in each of its candidate cells of a house, following singles: a placement or an
+// elimination made whatever the cell holding the value holds. Only the conclusion with the shortest proof is applied
+func (s *Sudoku) ResolveUnitForcingChainOptions() Step {
+	step := Step{Technique: "Unit Forcing Chain"}
+	conclusions := []forcingConclusion{}
+	for _, house := range s.Houses() {
+		for v := 1; v <= s.size; v++ {
+			cells := s.valueCells(house, v)
+			if len(cells) < 2 {
+				continue
+			}
+			assumptions := []Placement{}
+			for _, cell := range cells {
+				assumptions = append(assumptions, Placement{Cell: cell, Value: v})
+			}
+			if nets, ok := s.forcingBranches(assumptions); ok {
+				conclusions = append(conclusions, s.commonConclusions(nets)...)
+			}
+		}
+	}
+	if fc, found := shortestConclusion(conclusions); found {
+		s.applyForcing(&step, fc, "unit forcing chain")
+	}
+	return step
+}
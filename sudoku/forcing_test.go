@@ -0,0 +1,59 @@
+package sudoku
+
+import "testing"
+
+func TestSudoku_ResolveNishioOptions_Proof(t *testing.T) {
+	s := New(9)
+	// A1 and B1 make a naked pair on 1 and 2: placing 1 in C1 leaves 2 for both of them
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 1, 1, 2)
+	restrictCell(&s, 0, 2, 1, 3)
+
+	step := s.ResolveNishioOptions()
+	expect := "[1] from C1[1, 3] (contradiction net: C1=1 -> A1=2 (naked single) -> B1 has no candidate)"
+	for _, detail := range step.Details {
+		if detail == expect {
+			return
+		}
+	}
+	t.Errorf("expected %q in %s", expect, step.String())
+}
+
+func TestSudoku_propagate_Depth(t *testing.T) {
+	s, err := Parse(testHardPuzzles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	option := s.Candidates().Options()[0]
+	net := s.propagate(Placement{Cell: option.cell(), Value: option.GetValues()[0]})
+	// each round places at least one value
+	if len(net.nodes) == 0 || len(net.nodes) > maxForcingDepth*s.size*s.size {
+		t.Errorf("unexpected net size %d", len(net.nodes))
+	}
+	for i, node := range net.nodes {
+		for _, parent := range node.parents {
+			if parent >= i {
+				t.Errorf("node %s follows from later node %d", node.placement.String(), parent)
+			}
+		}
+	}
+}
+
+func TestSudoku_ResolveForcingOptions_Puzzles(t *testing.T) {
+	checkTechnique(t, StrategyNishio,
+		".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4",
+		"2..7..1..57..1..923.1...........76.1.........9....4.35..3.5..74..2.9......8..6...")
+
+	// cell and unit forcing chains apply once contradictions are exhausted
+	r := DefaultRegistry()
+	if err := r.Disable(StrategyNishio); err != nil {
+		t.Fatal(err)
+	}
+	solver := Solver{Strategies: r}
+	checkSolverTechnique(t, solver, StrategyCellForcing,
+		".4.1.....9....8.4..67..45....3.65.....2....95..43......8..9.7.....5...13........4",
+		"2..7..1..57..1..923.1...........76.1.........9....4.35..3.5..74..2.9......8..6...")
+	checkSolverTechnique(t, solver, StrategyUnitForcing,
+		"4.15..8..3......9..7...1..2.........81....4...2..6..5......4..5..71..9...8..5.24.",
+		"56...4.2.........7...8..6..8.1....3.9...51..237......9.....8......13.5.....7..2.4")
+}
@@ -0,0 +1,116 @@
+package sudoku
+
+import "sync"
+
+// geometry holds the house and peer tables of a grid geometry.
+//
+// Tables are computed once per box geometry, then shared by all sudokus with this geometry: they must not be modified
+type geometry struct {
+	size       int
+	houses     []House  // all houses: boxes, then rows, then columns (house index is Kind*size + Index)
+	houseCells [][]Cell // cells of each house, indexed by house index
+	cellHouses [][3]int // index of the box, row and column containing each cell (by cell index, then HouseKind)
+	peers      [][]Cell // cells sharing a house with each cell (by cell index)
+}
+
+var (
+	geometriesMutex sync.Mutex
+	geometries      = make(map[[2]int]*geometry)
+)
+
+// getGeometry returns the shared geometry of grids with boxWidth x boxHeight boxes
+func getGeometry(boxWidth, boxHeight int) *geometry {
+	geometriesMutex.Lock()
+	defer geometriesMutex.Unlock()
+	key := [2]int{boxWidth, boxHeight}
+	if geo, found := geometries[key]; found {
+		return geo
+	}
+	geo := newGeometry(boxWidth, boxHeight)
+	geometries[key] = geo
+	return geo
+}
+
+func newGeometry(boxWidth, boxHeight int) *geometry {
+	size := boxWidth * boxHeight
+	geo := &geometry{
+		size:       size,
+		houses:     make([]House, 0, 3*size),
+		houseCells: make([][]Cell, 3*size),
+		cellHouses: make([][3]int, size*size),
+		peers:      make([][]Cell, size*size),
+	}
+	for _, kind := range []HouseKind{HouseBox, HouseRow, HouseColumn} {
+		for i := 0; i < size; i++ {
+			geo.houses = append(geo.houses, House{Kind: kind, Index: i})
+		}
+	}
+	boxesPerBand := size / boxWidth
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			i := c + r*size
+			geo.cellHouses[i] = [3]int{HouseBox: r/boxHeight*boxesPerBand + c/boxWidth, HouseRow: r, HouseColumn: c}
+			for kind, index := range geo.cellHouses[i] {
+				h := kind*size + index
+				geo.houseCells[h] = append(geo.houseCells[h], Cell{Row: r, Col: c})
+			}
+		}
+	}
+	for i := range geo.peers {
+		isPeer := make(map[Cell]bool)
+		for kind, index := range geo.cellHouses[i] {
+			for _, cell := range geo.houseCells[kind*size+index] {
+				if cell.Col+cell.Row*size != i && !isPeer[cell] {
+					isPeer[cell] = true
+					geo.peers[i] = append(geo.peers[i], cell)
+				}
+			}
+		}
+	}
+	return geo
+}
+
+// house returns the house of given kind containing cell (row, col)
+func (geo *geometry) house(kind HouseKind, row, col int) House {
+	return House{Kind: kind, Index: geo.cellHouses[col+row*geo.size][kind]}
+}
+
+// sees returns true if cells a and b are distinct and share a house
+func (geo *geometry) sees(a, b Cell) bool {
+	if a == b {
+		return false
+	}
+	ha, hb := geo.cellHouses[a.Col+a.Row*geo.size], geo.cellHouses[b.Col+b.Row*geo.size]
+	return ha[HouseBox] == hb[HouseBox] || ha[HouseRow] == hb[HouseRow] || ha[HouseColumn] == hb[HouseColumn]
+}
+
+// Houses returns all houses of the receiver: boxes, then rows, then columns.
+//
+// Returned slice is shared by all sudokus with the same geometry, and must not be modified
+func (s Sudoku) Houses() []House {
+	return s.geo.houses
+}
+
+// housesOf returns all houses of given kind
+func (s Sudoku) housesOf(kind HouseKind) []House {
+	return s.geo.houses[int(kind)*s.size : int(kind+1)*s.size]
+}
+
+// HouseCells returns the cells of given house, from top left to bottom right.
+//
+// Returned slice is shared by all sudokus with the same geometry, and must not be modified
+func (s Sudoku) HouseCells(h House) []Cell {
+	return s.geo.houseCells[int(h.Kind)*s.size+h.Index]
+}
+
+// HouseOf returns the house of given kind containing cell (row, col)
+func (s Sudoku) HouseOf(kind HouseKind, row, col int) House {
+	return s.geo.house(kind, row, col)
+}
+
+// Peers returns the cells sharing a house (box, row or column) with cell (row, col), the cell itself excluded.
+//
+// Returned slice is shared by all sudokus with the same geometry, and must not be modified
+func (s Sudoku) Peers(row, col int) []Cell {
+	return s.geo.peers[col+row*s.size]
+}
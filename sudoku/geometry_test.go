@@ -0,0 +1,67 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSudoku_Houses(t *testing.T) {
+	s := New(9)
+	houses := s.Houses()
+	if len(houses) != 27 || houses[0] != (House{HouseBox, 0}) || houses[26] != (House{HouseColumn, 8}) {
+		t.Errorf("unexpected houses %v", houses)
+	}
+	expect := []Cell{{3, 3}, {3, 4}, {3, 5}, {4, 3}, {4, 4}, {4, 5}, {5, 3}, {5, 4}, {5, 5}}
+	if cells := s.HouseCells(House{HouseBox, 4}); !reflect.DeepEqual(cells, expect) {
+		t.Errorf("unexpected box 5 cells %v", cells)
+	}
+	if h := s.HouseOf(HouseBox, 7, 2); h != (House{HouseBox, 6}) {
+		t.Errorf("C8 should be in box 7, got %s", h.String())
+	}
+
+	s6 := NewWithBox(3, 2)
+	if h := s6.HouseOf(HouseBox, 2, 4); h != (House{HouseBox, 3}) {
+		t.Errorf("E3 should be in box 4 of a 6x6 grid, got %s", h.String())
+	}
+	if cells := s6.HouseCells(House{HouseBox, 3}); len(cells) != 6 || cells[0] != (Cell{2, 3}) || cells[5] != (Cell{3, 5}) {
+		t.Errorf("unexpected 6x6 box 4 cells %v", cells)
+	}
+
+	// tables are shared between sudokus with the same geometry
+	if s.geo != New(9).geo || s.geo != s.Clone().geo || s.geo == s6.geo {
+		t.Errorf("geometry tables should be shared by sudokus with the same geometry only")
+	}
+}
+
+func TestSudoku_Peers(t *testing.T) {
+	for _, tc := range []struct {
+		s      Sudoku
+		expect int
+	}{
+		{New(4), 7},
+		{NewWithBox(3, 2), 12},
+		{New(9), 20},
+		{New(16), 39},
+	} {
+		for r := 0; r < tc.s.size; r++ {
+			for c := 0; c < tc.s.size; c++ {
+				peers := tc.s.Peers(r, c)
+				if len(peers) != tc.expect {
+					t.Fatalf("%dx%d grid: got %d peers for %s, expected %d", tc.s.size, tc.s.size, len(peers), Cell{r, c}.String(), tc.expect)
+				}
+				for _, peer := range peers {
+					if peer == (Cell{r, c}) {
+						t.Fatalf("%s should not be its own peer", peer.String())
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSudoku_IsValid(b *testing.B) {
+	s, _ := Parse(testPuzzles[5].grid)
+	for i := 0; i < b.N; i++ {
+		s.IsValid(5, 4, 4)
+	}
+}
@@ -0,0 +1,106 @@
+package sudoku
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TrialAndErrorDifficulty is the rating of puzzles the default strategies can't solve without guessing, beyond the
+// difficulty of all logical techniques (Sudoku Explainer rates nested forcing chains 11 and more)
+const TrialAndErrorDifficulty = 11.0
+
+// ratingTiers gives the named difficulty ranges of ratings: a rating belongs to the first tier whose maximum score
+// is at least its score
+var ratingTiers = []struct {
+	name     string
+	maxScore float64
+}{
+	{"Very Easy", 2.3},  // singles
+	{"Easy", 2.8},       // intersections
+	{"Medium", 3.4},     // pairs, X-Wing
+	{"Difficult", 4.6},  // triplets, Swordfish, single digit patterns, wings, unique rectangles
+	{"Fiendish", 6.0},   // coloring, quads, Jellyfish, BUG, Sue de Coq
+	{"Diabolical", 8.0}, // chains, almost locked sets
+	{"Extreme", 10.9},   // Death Blossom, forcing chains and nets
+	{"Trial and Error", TrialAndErrorDifficulty},
+}
+
+// Rating is the difficulty of a puzzle, on the Sudoku Explainer scale: the difficulty of the hardest technique
+// needed to solve it
+type Rating struct {
+	Score   float64 // from 1.2 (hidden singles in boxes only) to 11 (guessing needed), 0 for a completed grid
+	Tier    string  // name of the difficulty range of Score
+	Hardest string  // name of the hardest technique applied, empty for a completed grid
+	Steps   int     // number of logical steps applied
+	Logical bool    // true if the puzzle was solved without guessing
+	Unique  bool    // true if the puzzle has a single solution (uniqueness techniques are only used then)
+}
+
+// BoxHiddenSinglesDifficulty is the rating of hidden singles found in boxes, easier to spot than those found only in
+// rows or columns (rated with the Hidden Singletons strategy difficulty)
+const BoxHiddenSinglesDifficulty = 1.2
+
+// easiestFirst returns given strategies sorted by increasing difficulty (keeping the order of strategies of the same
+// difficulty). Hidden singles are first searched in boxes only, with BoxHiddenSinglesDifficulty
+func easiestFirst(strategies []Strategy) []Strategy {
+	res := []Strategy{}
+	for _, st := range strategies {
+		if st.Name() == StrategyHiddenSingletons {
+			res = append(res, NewStrategy(StrategyHiddenSingletons, BoxHiddenSinglesDifficulty, func(s *Sudoku) Step {
+				return s.resolveHiddenSingletons(HouseBox)
+			}))
+		}
+		res = append(res, st)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Difficulty() < res[j].Difficulty()
+	})
+	return res
+}
+
+// Grade solves a copy of given puzzle with the default strategies, without guessing, and rates it with the
+// difficulty of the hardest technique applied. Like Sudoku Explainer, the easiest technique making a deduction is
+// applied at each step. Uniqueness techniques are used if the puzzle has a single solution.
+//
+// Puzzles which can't be solved logically (including puzzles with several solutions) get TrialAndErrorDifficulty
+func Grade(s Sudoku) Rating {
+	puzzle := s.Clone()
+	rating := Rating{Unique: puzzle.HasUniqueSolution()}
+	grid := puzzle.Candidates()
+	strategies := easiestFirst(Solver{AssumeUnique: rating.Unique}.strategies())
+	for len(grid.Options()) > 0 && !grid.Contradiction() {
+		strategy, _, found := applyFirst(&puzzle, strategies)
+		if !found {
+			break
+		}
+		rating.Steps++
+		if strategy.Difficulty() > rating.Score {
+			rating.Score, rating.Hardest = strategy.Difficulty(), strategy.Name()
+		}
+	}
+	rating.Logical = puzzle.Completed()
+	if !rating.Logical {
+		rating.Score = TrialAndErrorDifficulty
+	}
+	rating.Tier = tierOf(rating.Score)
+	return rating
+}
+
+// tierOf returns the name of the tier of given score
+func tierOf(score float64) string {
+	for _, tier := range ratingTiers {
+		if score <= tier.maxScore {
+			return tier.name
+		}
+	}
+	return ratingTiers[len(ratingTiers)-1].name
+}
+
+// String returns the rating score and tier, with the hardest technique for logically solved puzzles
+// ("4.2 Difficult (XY-Wing)")
+func (r Rating) String() string {
+	if r.Hardest == "" || !r.Logical {
+		return fmt.Sprintf("%.1f %s", r.Score, r.Tier)
+	}
+	return fmt.Sprintf("%.1f %s (%s)", r.Score, r.Tier, r.Hardest)
+}
@@ -0,0 +1,85 @@
+package sudoku
+
+import "testing"
+
+func TestGrade(t *testing.T) {
+	for _, tc := range []struct {
+		grid    string
+		score   float64
+		tier    string
+		hardest string
+	}{
+		{"76.9..2...8.5....6.21...58...8.......12.7......324.1....4....1.1...89.5..9...4...", 1.2, "Very Easy", StrategyHiddenSingletons}, // box hidden singles only
+		{"....9.4...68..75..7.4..6...37.............2.3.5..1...6.3.1..7....6.5.8.........4.", 1.5, "Very Easy", StrategyHiddenSingletons},
+		{testPuzzles[1].grid, 2.3, "Very Easy", StrategyObvious},
+		{testPuzzles[4].grid, 3.4, "Medium", StrategyHiddenPairs},
+		{testPuzzles[5].grid, 8.8, "Extreme", StrategyNishio},
+	} {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := Grade(s)
+		if r.Score != tc.score || r.Tier != tc.tier || r.Hardest != tc.hardest || !r.Logical || !r.Unique || r.Steps == 0 {
+			t.Errorf("%s: unexpected rating %+v", tc.grid, r)
+		}
+		if s.LineString() != tc.grid {
+			t.Errorf("%s: puzzle was modified", tc.grid)
+		}
+	}
+}
+
+func TestGrade_Singles(t *testing.T) {
+	s, err := Parse(testPuzzles[1].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SolveDLX()
+	if r := Grade(s); r.Score != 0 || r.Steps != 0 || !r.Logical {
+		t.Errorf("unexpected rating of a completed grid %+v", r)
+	}
+	// a single empty cell is found with a hidden single in its box, the easiest technique
+	s.SetValue(0, 4, 4)
+	if r := Grade(s); r.Score != BoxHiddenSinglesDifficulty || r.Tier != "Very Easy" || r.Hardest != StrategyHiddenSingletons || r.Steps != 1 {
+		t.Errorf("unexpected rating %+v", r)
+	}
+}
+
+func TestGrade_TrialAndError(t *testing.T) {
+	for _, tc := range []struct {
+		grid   string
+		unique bool
+	}{
+		{testHardPuzzles[0], true},
+		{testPuzzles[0].grid, false}, // several solutions
+	} {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := Grade(s)
+		if r.Score != TrialAndErrorDifficulty || r.Tier != "Trial and Error" || r.Logical || r.Unique != tc.unique {
+			t.Errorf("%s: unexpected rating %+v", tc.grid, r)
+		}
+		if r.String() != "11.0 Trial and Error" {
+			t.Errorf("unexpected rating string %q", r.String())
+		}
+	}
+}
+
+func TestTierOf(t *testing.T) {
+	for score, expect := range map[float64]string{
+		1.2:  "Very Easy",
+		2.3:  "Very Easy",
+		2.6:  "Easy",
+		4.2:  "Difficult",
+		7.0:  "Diabolical",
+		9.2:  "Extreme",
+		11.0: "Trial and Error",
+		12.5: "Trial and Error",
+	} {
+		if got := tierOf(score); got != expect {
+			t.Errorf("tier of %.1f: got %q, expected %q", score, got, expect)
+		}
+	}
+}
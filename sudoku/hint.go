@@ -0,0 +1,142 @@
+package sudoku
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrCompleted is returned by NextStep for a grid with all values defined
+	ErrCompleted = errors.New("sudoku: grid is already completed")
+	// ErrNoSolution is returned by NextStep for a grid which can't be completed, because of a wrong value or
+	// elimination
+	ErrNoSolution = errors.New("sudoku: grid has no solution")
+	// ErrNoLogicalStep is returned by NextStep when no technique makes a deduction: the grid can only be progressed by
+	// guessing (or has several solutions)
+	ErrNoLogicalStep = errors.New("sudoku: no logical step found")
+)
+
+// HintLevel gives how much of a Step is revealed by Step.Hint
+type HintLevel int
+
+const (
+	// HintTechnique reveals the technique name only ("Hidden Pairs")
+	HintTechnique HintLevel = iota
+	// HintRegion reveals the technique and the houses holding the affected cells ("Hidden Pairs in box 5")
+	HintRegion
+	// HintDeduction reveals the full deduction ("Hidden Pairs: E4-[1, 7] (...)")
+	HintDeduction
+)
+
+// NextStep returns the simplest deduction available for given grid, with the default strategies: the first deduction
+// of the easiest technique making one, as Grade does. Uniqueness techniques are used if the grid has a single
+// solution. The returned step holds this single deduction (one detail), and the pattern record it comes from if any.
+//
+// Given grid is not modified: the step is computed on a copy, with the candidates eliminated so far if any. Apply
+// returned placements and eliminations to progress
+func NextStep(s Sudoku) (Step, error) {
+	puzzle := s.Clone()
+	if puzzle.Completed() {
+		return Step{}, ErrCompleted
+	}
+	solutions := puzzle.CountSolutions(2)
+	if solutions == 0 || puzzle.Candidates().Contradiction() {
+		return Step{}, ErrNoSolution
+	}
+	strategies := easiestFirst(Solver{AssumeUnique: solutions == 1}.strategies())
+	_, step, found := applyFirst(&puzzle, strategies)
+	if !found {
+		return Step{}, ErrNoLogicalStep
+	}
+	step = step.firstDeduction()
+	step.Region = puzzle.regionOf(step.Cells())
+	return step, nil
+}
+
+// firstDeduction returns receiver restricted to its first deduction: the placements and eliminations made before the
+// first detail was recorded, this detail, and the first pattern record of each kind (records are added once their
+// deductions are made)
+func (st Step) firstDeduction() Step {
+	res := Step{Technique: st.Technique}
+	for i, p := range st.Placements {
+		if st.placementDetails[i] == 0 {
+			res.Placements = append(res.Placements, p)
+			res.placementDetails = append(res.placementDetails, 0)
+		}
+	}
+	for i, e := range st.Eliminations {
+		if st.eliminationDetails[i] == 0 {
+			res.Eliminations = append(res.Eliminations, e)
+			res.eliminationDetails = append(res.eliminationDetails, 0)
+		}
+	}
+	if len(st.Details) > 0 {
+		res.Details = st.Details[:1]
+	}
+	if len(st.Wings) > 0 {
+		res.Wings = st.Wings[:1]
+	}
+	if len(st.Colorings) > 0 {
+		res.Colorings = st.Colorings[:1]
+	}
+	if len(st.Chains) > 0 {
+		res.Chains = st.Chains[:1]
+	}
+	for i, set := range st.ALS {
+		if st.alsDetails[i] == st.alsDetails[0] {
+			res.ALS = append(res.ALS, set)
+		}
+	}
+	return res
+}
+
+// regionOf returns the first house (box, row then column) holding all given cells, or the boxes holding them
+func (s Sudoku) regionOf(cells []Cell) []House {
+	if len(cells) == 0 {
+		return nil
+	}
+	for _, kind := range []HouseKind{HouseBox, HouseRow, HouseColumn} {
+		house := s.geo.house(kind, cells[0].Row, cells[0].Col)
+		shared := true
+		for _, c := range cells[1:] {
+			if s.geo.house(kind, c.Row, c.Col) != house {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			return []House{house}
+		}
+	}
+	boxes := []House{}
+	known := make(map[House]bool)
+	for _, c := range cells {
+		if box := s.geo.house(HouseBox, c.Row, c.Col); !known[box] {
+			known[box] = true
+			boxes = append(boxes, box)
+		}
+	}
+	sort.Slice(boxes, func(i, j int) bool {
+		return boxes[i].Index < boxes[j].Index
+	})
+	return boxes
+}
+
+// Hint returns the receiver description revealed at given level: the technique name, then the houses to look at,
+// then the deductions made
+func (st Step) Hint(level HintLevel) string {
+	switch {
+	case level <= HintTechnique:
+		return st.Technique
+	case level == HintRegion && len(st.Region) == 1:
+		return fmt.Sprintf("%s in %s", st.Technique, st.Region[0])
+	case level == HintRegion && len(st.Region) > 1:
+		return fmt.Sprintf("%s in %s", st.Technique, housesString(st.Region))
+	case level == HintRegion:
+		return st.Technique
+	default:
+		return fmt.Sprintf("%s: %s", st.Technique, strings.Join(st.Details, ", "))
+	}
+}
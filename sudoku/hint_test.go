@@ -0,0 +1,168 @@
+package sudoku
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNextStep(t *testing.T) {
+	for _, tc := range []struct {
+		grid   string
+		region string
+	}{
+		{testPuzzles[4].grid, "Hidden Pairs in row 6"},
+		{testPuzzles[5].grid, "Hidden Singletons in box 9"},
+	} {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution := s.Clone()
+		solution.SolveDLX()
+		candidates := s.Candidates().Options().String()
+		step, err := NextStep(s)
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.grid, err)
+		}
+		if hint := step.Hint(HintRegion); hint != tc.region || !step.Found() || len(step.Details) != 1 {
+			t.Errorf("%s: unexpected step %s (%s)", tc.grid, step, hint)
+		}
+		if s.LineString() != tc.grid || s.Candidates().Options().String() != candidates {
+			t.Errorf("%s: puzzle was modified", tc.grid)
+		}
+		for _, p := range step.Placements {
+			if solution.GetValue(p.Row, p.Col) != p.Value {
+				t.Errorf("%s: wrong placement %s", tc.grid, p)
+			}
+		}
+		for _, e := range step.Eliminations {
+			if NewValueSet(e.Values...).Has(solution.GetValue(e.Row, e.Col)) {
+				t.Errorf("%s: wrong elimination %s", tc.grid, e)
+			}
+			// deduction made on the given grid, not after other deductions
+			for _, v := range e.Values {
+				if !s.Candidates().Has(e.Row, e.Col, v) {
+					t.Errorf("%s: elimination %s of a value not candidate", tc.grid, e)
+				}
+			}
+		}
+	}
+}
+
+func TestStep_FirstDeduction(t *testing.T) {
+	s, err := Parse(testPuzzles[4].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	step := s.ResolveHiddenPairsOptions()
+	if len(step.Details) < 2 {
+		t.Fatalf("expected several deductions, got %s", step)
+	}
+	first := step.firstDeduction()
+	if len(first.Details) != 1 || first.Details[0] != step.Details[0] {
+		t.Errorf("unexpected details %v", first.Details)
+	}
+	if len(first.Eliminations) == 0 || len(first.Eliminations) >= len(step.Eliminations) {
+		t.Errorf("unexpected eliminations %v (from %v)", first.Eliminations, step.Eliminations)
+	}
+	for i, e := range first.Eliminations {
+		if e.Cell != step.Eliminations[i].Cell {
+			t.Errorf("elimination %d: expected %s, got %s", i, step.Eliminations[i], e)
+		}
+	}
+}
+
+func TestNextStep_Solve(t *testing.T) {
+	// apply hints one by one, keeping eliminations made so far
+	s, err := Parse(testPuzzles[4].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	solution := s.Clone()
+	solution.SolveDLX()
+	steps := 0
+	for ; steps < 100; steps++ {
+		step, err := NextStep(s)
+		if errors.Is(err, ErrCompleted) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("step %d: unexpected error %v", steps, err)
+		}
+		for _, p := range step.Placements {
+			s.SetValue(p.Value, p.Row, p.Col)
+		}
+		for _, e := range step.Eliminations {
+			s.Candidates().Eliminate(e.Row, e.Col, NewValueSet(e.Values...))
+		}
+	}
+	if s.LineString() != solution.LineString() {
+		t.Errorf("puzzle not solved after %d steps:\n%s", steps, s.String())
+	}
+}
+
+func TestNextStep_Errors(t *testing.T) {
+	completed, err := Parse(testPuzzles[1].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completed.SolveDLX()
+
+	// a value which doesn't conflict with its peers, but differs from the solution
+	wrong, _ := Parse(testPuzzles[1].grid)
+	for _, opt := range wrong.Candidates().Options() {
+		if values := opt.GetValues(); len(values) > 1 {
+			v := values[0]
+			if v == completed.GetValue(opt.row, opt.col) {
+				v = values[1]
+			}
+			wrong.SetValue(v, opt.row, opt.col)
+			break
+		}
+	}
+
+	hard, err := Parse(testHardPuzzles[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		grid Sudoku
+		err  error
+	}{
+		{"completed", completed, ErrCompleted},
+		{"wrong value", wrong, ErrNoSolution},
+		{"no logical step", hard, ErrNoLogicalStep},
+		{"several solutions", New(4), ErrNoLogicalStep},
+	} {
+		if step, err := NextStep(tc.grid); !errors.Is(err, tc.err) || step.Found() {
+			t.Errorf("%s: expected %v, got %v (%s)", tc.name, tc.err, err, step)
+		}
+	}
+}
+
+func TestStep_Hint(t *testing.T) {
+	step := Step{
+		Technique:    StrategyHiddenPairs,
+		Eliminations: []Elimination{{Cell{0, 5}, []int{8}}, {Cell{8, 5}, []int{5}}},
+		Details:      []string{"[3, 7] from A6[3, 7, 8] / I6[3, 5, 7] in row 6"},
+		Region:       []House{{HouseRow, 5}},
+	}
+	for _, tc := range []struct {
+		level HintLevel
+		hint  string
+	}{
+		{HintTechnique, "Hidden Pairs"},
+		{HintRegion, "Hidden Pairs in row 6"},
+		{HintDeduction, "Hidden Pairs: [3, 7] from A6[3, 7, 8] / I6[3, 5, 7] in row 6"},
+	} {
+		if hint := step.Hint(tc.level); hint != tc.hint {
+			t.Errorf("level %d: expected %q, got %q", tc.level, tc.hint, hint)
+		}
+	}
+	step.Region = []House{{HouseBox, 4}, {HouseBox, 0}}
+	if hint := step.Hint(HintRegion); hint != "Hidden Pairs in boxes 5, 1" {
+		t.Errorf("unexpected hint %q", hint)
+	}
+}
@@ -0,0 +1,73 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HouseKind gives the kind of a House
+type HouseKind int
+
+const (
+	HouseBox HouseKind = iota
+	HouseRow
+	HouseColumn
+)
+
+// House identifies a group of cells holding each value exactly once: a box, a row or a column.
+//
+// Houses of each kind are numbered from 0, boxes being numbered from left to right then top to bottom
+type House struct {
+	Kind  HouseKind
+	Index int
+}
+
+// String returns the house name, using 1-based row and box numbers and column letters ("box 1", "row 1", "column A")
+func (h House) String() string {
+	switch h.Kind {
+	case HouseRow:
+		return fmt.Sprintf("row %d", h.Index+1)
+	case HouseColumn:
+		return fmt.Sprintf("column %c", 'A'+h.Index)
+	default:
+		return fmt.Sprintf("box %d", h.Index+1)
+	}
+}
+
+// containsKind returns true if kind is one of given kinds
+func containsKind(kinds []HouseKind, kind HouseKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// housesString returns the names of given houses, all of the same kind, as a list ("rows 2, 7", "columns B, H" or "boxes 1, 5")
+func housesString(houses []House) string {
+	if len(houses) == 0 {
+		return ""
+	}
+	names := make([]string, len(houses))
+	for i, h := range houses {
+		names[i] = strings.TrimPrefix(h.String(), h.kindName()+" ")
+	}
+	plural := houses[0].kindName() + "s"
+	if houses[0].Kind == HouseBox {
+		plural = "boxes"
+	}
+	return fmt.Sprintf("%s %s", plural, strings.Join(names, ", "))
+}
+
+// kindName returns the name of the receiver kind ("box", "row" or "column")
+func (h House) kindName() string {
+	switch h.Kind {
+	case HouseRow:
+		return "row"
+	case HouseColumn:
+		return "column"
+	default:
+		return "box"
+	}
+}
@@ -0,0 +1,73 @@
+package sudoku
+
+import "fmt"
+
+// inHouse returns true if all given cells belong to given house
+func (s Sudoku) inHouse(cells []Cell, h House) bool {
+	for _, cell := range cells {
+		if s.geo.house(h.Kind, cell.Row, cell.Col) != h {
+			return false
+		}
+	}
+	return true
+}
+
+// removeFromHouse removes value from the candidates of cells of house target located outside of house source,
+// describing each elimination in step with given reason
+func (s *Sudoku) removeFromHouse(step *Step, value int, target, source House, reason string) {
+	grid := s.Candidates()
+	for _, cell := range s.HouseCells(target) {
+		if s.geo.house(source.Kind, cell.Row, cell.Col) == source || !grid.Has(cell.Row, cell.Col, value) {
+			continue
+		}
+		option := grid.option(cell)
+		s.eliminate(step, cell, NewValueSet(value))
+		step.Details = append(step.Details, fmt.Sprintf("%s from %s in %s (%s %s)", NewValueSet(value).String(), option.String(), target.String(), reason, source.String()))
+	}
+}
+
+// ResolvePointingOptions based on https://www.sudokuwiki.org/Intersection_Removal
+//
+// When all candidates for a value within a box are on the same row (or column), the value must be set in this box
+// part of the row, so it can be removed from the rest of the row
+func (s *Sudoku) ResolvePointingOptions() Step {
+	step := Step{Technique: "Pointing"}
+	for _, box := range s.housesOf(HouseBox) {
+		for v := 1; v <= s.size; v++ {
+			cells := s.valueCells(box, v)
+			if len(cells) < 2 { // hidden singleton, or value already set in box
+				continue
+			}
+			for _, kind := range []HouseKind{HouseRow, HouseColumn} {
+				line := s.geo.house(kind, cells[0].Row, cells[0].Col)
+				if s.inHouse(cells, line) {
+					s.removeFromHouse(&step, v, line, box, "pointing from")
+				}
+			}
+		}
+	}
+	return step
+}
+
+// ResolveBoxLineReductionOptions based on https://www.sudokuwiki.org/Intersection_Removal
+//
+// When all candidates for a value within a row (or column) are in the same box, the value must be set in this row
+// part of the box, so it can be removed from the rest of the box (also known as Claiming)
+func (s *Sudoku) ResolveBoxLineReductionOptions() Step {
+	step := Step{Technique: "Box/Line Reduction"}
+	for _, kind := range []HouseKind{HouseRow, HouseColumn} {
+		for _, line := range s.housesOf(kind) {
+			for v := 1; v <= s.size; v++ {
+				cells := s.valueCells(line, v)
+				if len(cells) < 2 { // hidden singleton, or value already set in line
+					continue
+				}
+				box := s.geo.house(HouseBox, cells[0].Row, cells[0].Col)
+				if s.inHouse(cells, box) {
+					s.removeFromHouse(&step, v, box, line, "claimed by")
+				}
+			}
+		}
+	}
+	return step
+}
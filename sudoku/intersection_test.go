@@ -0,0 +1,64 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSudoku_ResolvePointingOptions(t *testing.T) {
+	checkTechnique(t, StrategyPointing, uniqueTestGrids(t)...)
+}
+
+func TestSudoku_ResolvePointingOptions_Row(t *testing.T) {
+	s := New(9)
+	// 5 only in row 1 of box 1, and in E1 for the rest of row 1
+	for r := 1; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			s.Candidates().Eliminate(r, c, NewValueSet(5))
+		}
+	}
+	for c := 3; c < 9; c++ {
+		if c != 4 {
+			s.Candidates().Eliminate(0, c, NewValueSet(5))
+		}
+	}
+	restrictCell(&s, 0, 4, 5, 8)
+
+	step := s.ResolvePointingOptions()
+	expect := []Elimination{{Cell: Cell{0, 4}, Values: []int{5}}}
+	if !reflect.DeepEqual(step.Eliminations, expect) {
+		t.Errorf("got eliminations %v, expected %v", step.Eliminations, expect)
+	}
+	if res := step.String(); res != "Pointing: x1 ([5] from E1[5, 8] in row 1 (pointing from box 1))" {
+		t.Errorf("unexpected step %s", res)
+	}
+}
+
+func TestSudoku_ResolveBoxLineReductionOptions(t *testing.T) {
+	checkTechnique(t, StrategyBoxLineReduction, uniqueTestGrids(t)...)
+}
+
+func TestSudoku_ResolveBoxLineReductionOptions_Row(t *testing.T) {
+	s := New(9)
+	// 7 only in box 1 for row 1, and in B2 for the rest of box 1
+	for c := 2; c < 9; c++ {
+		s.Candidates().Eliminate(0, c, NewValueSet(7))
+	}
+	for r := 1; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			if r != 1 || c != 1 {
+				s.Candidates().Eliminate(r, c, NewValueSet(7))
+			}
+		}
+	}
+	restrictCell(&s, 1, 1, 7, 9)
+
+	step := s.ResolveBoxLineReductionOptions()
+	expect := []Elimination{{Cell: Cell{1, 1}, Values: []int{7}}}
+	if !reflect.DeepEqual(step.Eliminations, expect) {
+		t.Errorf("got eliminations %v, expected %v", step.Eliminations, expect)
+	}
+	if res := step.String(); res != "Box/Line Reduction: x1 ([7] from B2[7, 9] in box 1 (claimed by row 1))" {
+		t.Errorf("unexpected step %s", res)
+	}
+}
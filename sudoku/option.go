@@ -11,7 +11,7 @@ type Option struct {
 }
 
 func (o Option) Length() int {
-	return len(o.option)
+	return o.option.Length()
 }
 
 func (o Option) GetValues() []int {
@@ -22,6 +22,10 @@ func (o Option) String() string {
 	return fmt.Sprintf("%s%s", o.posString(), o.option.String())
 }
 
+func (o Option) cell() Cell {
+	return Cell{Row: o.row, Col: o.col}
+}
+
 func (o Option) posString() string {
 	col := 'A' + o.col
 	return fmt.Sprintf("%c%d", col, o.row+1)
@@ -41,11 +45,13 @@ func (o Options) String() string {
 }
 
 func (o Options) SortByLength() {
-	sort.Slice(o, func(i, j int) bool {
-		return len(o[i].option) < len(o[j].option)
+	sort.SliceStable(o, func(i, j int) bool {
+		return o[i].option.Length() < o[j].option.Length()
 	})
 }
 
+// Filter returns the options kept by given function, in receiver order (so a receiver sorted by length gives a
+// result sorted by length)
 func (o Options) Filter(keep func(opt Option) bool) Options {
 	res := Options{}
 	for _, option := range o {
@@ -53,7 +59,6 @@ func (o Options) Filter(keep func(opt Option) bool) Options {
 			res = append(res, option)
 		}
 	}
-	res.SortByLength()
 	return res
 }
 
@@ -65,12 +70,11 @@ func FilterColFunc(col int) func(opt Option) bool {
 	return func(opt Option) bool { return opt.col == col }
 }
 
-func FilterSubSquareFunc(row, col int) func(opt Option) bool {
-	rMin := row / 3 * 3
-	rMax := rMin + 2
-	cMin := col / 3 * 3
-	cMax := cMin + 2
-	return func(opt Option) bool { return opt.row >= rMin && opt.row <= rMax && opt.col >= cMin && opt.col <= cMax }
+// FilterSubSquareFunc returns a filter keeping options located within given subsquare bounds (inclusive)
+func FilterSubSquareFunc(rowMin, rowMax, colMin, colMax int) func(opt Option) bool {
+	return func(opt Option) bool {
+		return opt.row >= rowMin && opt.row <= rowMax && opt.col >= colMin && opt.col <= colMax
+	}
 }
 
 func (o Options) GetRowOptions(row int) Options {
@@ -81,6 +85,6 @@ func (o Options) GetColumnOptions(col int) Options {
 	return o.Filter(FilterColFunc(col))
 }
 
-func (o Options) GetSubScareOptions(row, col int) Options {
-	return o.Filter(FilterSubSquareFunc(row, col))
+func (o Options) GetSubScareOptions(rowMin, rowMax, colMin, colMax int) Options {
+	return o.Filter(FilterSubSquareFunc(rowMin, rowMax, colMin, colMax))
 }
@@ -0,0 +1,191 @@
+package sudoku
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseError reports an invalid character found while parsing a sudoku
+type ParseError struct {
+	Line int  // line number (1-based) of the offending character within parsed text
+	Row  int  // row (0-based) of the offending cell
+	Col  int  // column (0-based) of the offending cell
+	Char rune // offending character
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("sudoku: line %d: invalid character %q at %s (row %d, column %c)",
+		e.Line, e.Char, Option{row: e.Row, col: e.Col}.posString(), e.Row+1, 'A'+e.Col)
+}
+
+// Parse returns the Sudoku described by given string.
+//
+// Two formats are supported:
+//
+//   - the one-line format: one character per cell, row by row, using digits (then letters A..P for grids
+//     larger than 9x9) for values and '0', '.' or '_' for blanks. Whitespace (including line breaks) is ignored,
+//     so the same format spread over several lines is accepted. Grid size is deduced from the number of cells,
+//     and box geometry is the one chosen by New.
+//   - the grid format, as produced by Sudoku.String(): a header line with column names, then one line per row
+//     starting with row number, with boxes separated by '|' and separator lines. Box geometry is deduced
+//     from separators.
+func Parse(str string) (Sudoku, error) {
+	lines := strings.Split(strings.ReplaceAll(str, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if isGridHeader(line) {
+			return parseGrid(lines[i:], i+1)
+		}
+		break
+	}
+	return parseLines(lines)
+}
+
+// parseValue returns the value given by character ch for a grid of given size.
+//
+// Blank characters return valueUndef. ok is false if ch is not a valid value for the grid size
+func parseValue(ch rune, size int) (value int, ok bool) {
+	switch ch {
+	case '0', '.', '_':
+		return valueUndef, true
+	}
+	value = strings.IndexRune(valueSymbols, unicode.ToUpper(ch)) + 1
+	if value < 1 || value > size {
+		return valueError, false
+	}
+	return value, true
+}
+
+// parseLines parses the one-line format, possibly spread over several lines
+func parseLines(lines []string) (Sudoku, error) {
+	type cell struct {
+		char rune
+		line int
+	}
+	cells := []cell{}
+	for l, line := range lines {
+		for _, ch := range line {
+			if unicode.IsSpace(ch) {
+				continue
+			}
+			cells = append(cells, cell{char: ch, line: l + 1})
+		}
+	}
+
+	size := 0
+	for size*size < len(cells) {
+		size++
+	}
+	if size*size != len(cells) || !validSize(size) {
+		return Sudoku{}, fmt.Errorf("sudoku: %d cells do not make a supported square grid", len(cells))
+	}
+
+	s := New(size)
+	for i, cell := range cells {
+		value, ok := parseValue(cell.char, size)
+		if !ok {
+			return Sudoku{}, &ParseError{Line: cell.line, Row: i / size, Col: i % size, Char: cell.char}
+		}
+		s.values[i] = value
+	}
+	return s, nil
+}
+
+// validSize returns true if a grid of given size can be split in boxes accepted by ValidateBox (see New)
+func validSize(size int) bool {
+	return size > 0 && ValidateBox(boxDimensions(size)) == nil
+}
+
+// isGridHeader returns true if given line is a column header line as printed by Sudoku.String()
+func isGridHeader(line string) bool {
+	col := 0
+	for _, field := range strings.Fields(line) {
+		if field == "." {
+			continue
+		}
+		if field != string(rune('A'+col)) {
+			return false
+		}
+		col++
+	}
+	return col >= 4
+}
+
+// parseGrid parses the grid format. lines[0] is the header line, found at line number firstLine of parsed text
+func parseGrid(lines []string, firstLine int) (Sudoku, error) {
+	// get column positions and box width from header
+	header := lines[0]
+	colPos := []int{}
+	boxWidth := 0
+	for i, ch := range []rune(header) {
+		switch {
+		case ch == '.' && boxWidth == 0:
+			boxWidth = len(colPos)
+		case ch >= 'A' && ch <= 'Z':
+			colPos = append(colPos, i)
+		}
+	}
+	size := len(colPos)
+	if boxWidth == 0 {
+		boxWidth = size
+	}
+
+	rows := [][]int{}
+	boxHeight := 0
+	for l, line := range lines[1:] {
+		lineNum := firstLine + l + 1
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "-") { // box separator line
+			if boxHeight == 0 {
+				boxHeight = len(rows)
+			}
+			continue
+		}
+		rowNum, err := strconv.Atoi(fields[0])
+		if err != nil || rowNum != len(rows)+1 {
+			return Sudoku{}, fmt.Errorf("sudoku: line %d: expected row number %d, got %q", lineNum, len(rows)+1, fields[0])
+		}
+		if len(rows) == size {
+			return Sudoku{}, fmt.Errorf("sudoku: line %d: too many rows for a %dx%d grid", lineNum, size, size)
+		}
+		runes := []rune(line)
+		row := make([]int, size)
+		for c, pos := range colPos {
+			ch := ' '
+			if pos < len(runes) {
+				ch = runes[pos]
+			}
+			if ch == ' ' {
+				continue
+			}
+			value, ok := parseValue(ch, size)
+			if !ok {
+				return Sudoku{}, &ParseError{Line: lineNum, Row: len(rows), Col: c, Char: ch}
+			}
+			row[c] = value
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) != size {
+		return Sudoku{}, fmt.Errorf("sudoku: found %d rows for a %dx%d grid", len(rows), size, size)
+	}
+	if boxHeight == 0 {
+		boxHeight = size
+	}
+	if boxWidth*boxHeight != size || ValidateBox(boxWidth, boxHeight) != nil {
+		return Sudoku{}, fmt.Errorf("sudoku: unsupported %dx%d grid with %dx%d boxes", size, size, boxWidth, boxHeight)
+	}
+
+	s := NewWithBox(boxWidth, boxHeight)
+	for r, row := range rows {
+		copy(s.values[r*size:], row)
+	}
+	return s, nil
+}
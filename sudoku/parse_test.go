@@ -0,0 +1,104 @@
+package sudoku
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	expect := New(9)
+	expect.values = []int{
+		0, 0, 8, 0, 0, 7, 0, 0, 0,
+		0, 4, 2, 0, 0, 5, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0,
+
+		0, 0, 3, 0, 0, 6, 8, 0, 1,
+		0, 0, 0, 0, 0, 0, 0, 0, 6,
+		9, 0, 0, 0, 0, 0, 0, 0, 0,
+
+		0, 8, 0, 1, 3, 0, 4, 7, 0,
+		0, 0, 0, 0, 9, 0, 0, 0, 0,
+		0, 1, 0, 0, 0, 0, 0, 0, 0,
+	}
+
+	for _, input := range []string{
+		"..8..7....42..5..............3..68.1........69.........8.13.47.....9.....1.......",
+		"008007000042005000000000000003006801000000006900000000080130470000090000010000000",
+		"__8__7____42__5______________3__68_1________69_________8_13_47_____9_____1_______",
+		"  ..8 ..7 ...\n  .42 ..5 ...\n ... ... ...\n..3 ..6 8.1\n... ... ..6\n9.. ... ...\n.8. 13. 47.\n... .9. ...\n.1. ... ...\n",
+		expect.String(),
+	} {
+		s, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q) returned unexpected error: %v", input, err)
+			continue
+		}
+		if s.String() != expect.String() {
+			t.Errorf("Parse(%q) returned\n%s\nexpected\n%s", input, s.String(), expect.String())
+		}
+	}
+}
+
+func TestParse_Geometry(t *testing.T) {
+	s := NewWithBox(2, 3)
+	s.SetValue(6, 0, 0)
+	s.SetValue(3, 5, 5)
+	p, err := Parse(s.String())
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+	if w, h := p.BoxSize(); w != 2 || h != 3 {
+		t.Errorf("Parse returned %dx%d boxes, expected 2x3", w, h)
+	}
+	if p.String() != s.String() {
+		t.Errorf("Parse returned\n%s\nexpected\n%s", p.String(), s.String())
+	}
+
+	p, err = Parse(strings.Repeat("G..F", 64))
+	if err != nil {
+		t.Fatalf("Parse returned unexpected error: %v", err)
+	}
+	if p.Size() != 16 || p.GetValue(0, 0) != 16 || p.GetValue(15, 15) != 15 {
+		t.Errorf("unexpected 16x16 parse result:\n%s", p.String())
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		input          string
+		line, row, col int
+		char           rune
+	}{
+		{"..8..7....42..5..............3..68.1........69....x....8.13.47.....9.....1.......", 1, 5, 5, 'x'},
+		{"..8..7...\n.42..5...\n.........\n..3..68.1\n.......A6\n9........\n.8.13.47.\n....9....\n.1.......\n", 5, 4, 7, 'A'},
+		{"..8..7...\n.42..5...\n", 0, 0, 0, 0},
+	} {
+		_, err := Parse(tc.input)
+		if err == nil {
+			t.Errorf("Parse(%q) should return an error", tc.input)
+			continue
+		}
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			if tc.char != 0 {
+				t.Errorf("Parse(%q) returned %v, expected a ParseError", tc.input, err)
+			}
+			continue
+		}
+		if perr.Line != tc.line || perr.Row != tc.row || perr.Col != tc.col || perr.Char != tc.char {
+			t.Errorf("Parse(%q) returned %+v", tc.input, perr)
+		}
+		t.Log(err)
+	}
+
+	grid := strings.Replace(New(9).String(), "   3    ", "   3   x", 1)
+	_, err := Parse(grid)
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 4 || perr.Row != 2 || perr.Col != 0 || perr.Char != 'x' {
+		t.Errorf("Parse returned %v, expected error on A3 at line 4", err)
+	}
+	if _, err := Parse(strings.Repeat(".", 80)); err == nil {
+		t.Errorf("Parse should reject 80 cells")
+	}
+}
@@ -0,0 +1,153 @@
+package sudoku
+
+import "fmt"
+
+// conjugatePair is a strong link on a value: the two only cells of a house having the value as candidate.
+// One of them must hold the value
+type conjugatePair struct {
+	house House
+	cells [2]Cell
+}
+
+// conjugatePairs returns the conjugate pairs of given value, in all houses
+func (s *Sudoku) conjugatePairs(value int) []conjugatePair {
+	res := []conjugatePair{}
+	for _, house := range s.Houses() {
+		if cells := s.valueCells(house, value); len(cells) == 2 {
+			res = append(res, conjugatePair{house: house, cells: [2]Cell{cells[0], cells[1]}})
+		}
+	}
+	return res
+}
+
+// turbotTechnique returns the name of the turbot fish made of strong links a1=a2 in house ha and b1=b2 in house hb,
+// a2 and b2 seeing each other
+func (s Sudoku) turbotTechnique(ha, hb House, a2, b2 Cell) string {
+	switch {
+	case ha.Kind != HouseBox && ha.Kind == hb.Kind && s.geo.house(crossKind(ha.Kind), a2.Row, a2.Col) == s.geo.house(crossKind(ha.Kind), b2.Row, b2.Col):
+		return "Skyscraper"
+	case ha.Kind != HouseBox && hb.Kind != HouseBox && ha.Kind != hb.Kind && s.geo.house(HouseBox, a2.Row, a2.Col) == s.geo.house(HouseBox, b2.Row, b2.Col):
+		return "2-String Kite"
+	default:
+		return "Turbot Fish"
+	}
+}
+
+// resolveTurbotFish searches, for each value, two strong links a1=a2 and b1=b2 such that a2 sees b2 (a weak link):
+// a2 and b2 can't both hold the value, so a1 or b1 holds it, and the value can be removed from the cells seeing both
+// a1 and b1. Only the patterns whose name is step technique (Skyscraper, 2-String Kite or Turbot Fish) are searched
+func (s *Sudoku) resolveTurbotFish(step *Step) {
+	grid := s.Candidates()
+	for v := 1; v <= s.size; v++ {
+		pairs := s.conjugatePairs(v)
+		for i, pa := range pairs {
+			for _, pb := range pairs[i+1:] {
+				for _, a := range [][2]Cell{pa.cells, {pa.cells[1], pa.cells[0]}} {
+					for _, b := range [][2]Cell{pb.cells, {pb.cells[1], pb.cells[0]}} {
+						a1, a2, b1, b2 := a[0], a[1], b[0], b[1]
+						if a1 == b1 || a1 == b2 || a2 == b1 || a2 == b2 || !s.geo.sees(a2, b2) {
+							continue
+						}
+						if s.turbotTechnique(pa.house, pb.house, a2, b2) != step.Technique {
+							continue
+						}
+						for _, cell := range s.seenByAll([]Cell{a1, b1}) {
+							if !grid.Has(cell.Row, cell.Col, v) {
+								continue
+							}
+							option := grid.option(cell)
+							s.eliminate(step, cell, NewValueSet(v))
+							step.Details = append(step.Details, fmt.Sprintf("%s from %s (%s=%s in %s / %s=%s in %s)", NewValueSet(v).String(), option.String(), a1.String(), a2.String(), pa.house.String(), b2.String(), b1.String(), pb.house.String()))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// ResolveSkyscraperOptions based on https://www.sudokuwiki.org/Turbot_Fish
+//
+// Two strong links in parallel lines (rows, or columns), with one end of each link in the same crossing line
+func (s *Sudoku) ResolveSkyscraperOptions() Step {
+	step := Step{Technique: "Skyscraper"}
+	s.resolveTurbotFish(&step)
+	return step
+}
+
+// ResolveTwoStringKiteOptions based on https://www.sudokuwiki.org/Turbot_Fish
+//
+// A strong link in a row and a strong link in a column, with one end of each link in the same box
+func (s *Sudoku) ResolveTwoStringKiteOptions() Step {
+	step := Step{Technique: "2-String Kite"}
+	s.resolveTurbotFish(&step)
+	return step
+}
+
+// ResolveTurbotFishOptions based on https://www.sudokuwiki.org/Turbot_Fish
+//
+// Other strong link pairs connected by a weak link, including strong links in boxes
+func (s *Sudoku) ResolveTurbotFishOptions() Step {
+	step := Step{Technique: "Turbot Fish"}
+	s.resolveTurbotFish(&step)
+	return step
+}
+
+// ResolveEmptyRectangleOptions based on https://www.sudokuwiki.org/Empty_Rectangles
+//
+// When the candidates of a value within a box all lie in one row and one column of the box (the other box cells
+// making an empty rectangle), the value is in this row or in this column. Given a strong link on the value in a line
+// crossing the box row (or column) outside of the box, either its end in the box row holds the value, forcing the box
+// value into the box column, or its other end does: the value can be removed from the cell at the crossing of the
+// box column and of the line of the other end
+func (s *Sudoku) ResolveEmptyRectangleOptions() Step {
+	step := Step{Technique: "Empty Rectangle"}
+	grid := s.Candidates()
+	for v := 1; v <= s.size; v++ {
+		pairs := s.conjugatePairs(v)
+		for _, box := range s.housesOf(HouseBox) {
+			cells := s.valueCells(box, v)
+			if len(cells) < 2 {
+				continue
+			}
+			for _, center := range s.HouseCells(box) {
+				// box candidates must be in center row or column, with some of them outside of each
+				offRow, offCol := false, false
+				inCross := true
+				for _, cell := range cells {
+					inCross = inCross && (cell.Row == center.Row || cell.Col == center.Col)
+					offRow = offRow || cell.Row != center.Row
+					offCol = offCol || cell.Col != center.Col
+				}
+				if !inCross || !offRow || !offCol {
+					continue
+				}
+				for _, pair := range pairs {
+					if pair.house.Kind == HouseBox {
+						continue
+					}
+					for _, ends := range [][2]Cell{pair.cells, {pair.cells[1], pair.cells[0]}} {
+						// ends[0] in box row (or column) outside of box, target at the crossing of box column (or row)
+						// and of ends[1] row (or column)
+						var target Cell
+						switch {
+						case pair.house.Kind == HouseColumn && ends[0].Row == center.Row:
+							target = Cell{Row: ends[1].Row, Col: center.Col}
+						case pair.house.Kind == HouseRow && ends[0].Col == center.Col:
+							target = Cell{Row: center.Row, Col: ends[1].Col}
+						default:
+							continue
+						}
+						if s.geo.house(HouseBox, ends[0].Row, ends[0].Col) == box || s.geo.house(HouseBox, target.Row, target.Col) == box || !grid.Has(target.Row, target.Col, v) {
+							continue
+						}
+						option := grid.option(target)
+						s.eliminate(&step, target, NewValueSet(v))
+						step.Details = append(step.Details, fmt.Sprintf("%s from %s (empty rectangle in %s on %s / %s, %s=%s in %s)", NewValueSet(v).String(), option.String(), box.String(), s.geo.house(HouseRow, center.Row, center.Col).String(), s.geo.house(HouseColumn, center.Row, center.Col).String(), ends[0].String(), ends[1].String(), pair.house.String()))
+					}
+				}
+			}
+		}
+	}
+	return step
+}
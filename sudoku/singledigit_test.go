@@ -0,0 +1,70 @@
+package sudoku
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSudoku_ResolveSkyscraperOptions(t *testing.T) {
+	s := New(9)
+	// 6 only possible in A1 and F1 in row 1, and in A4 and D4 in row 4: A1 and A4 can't both hold 6,
+	// so F1 or D4 does
+	restrictLine(&s, HouseRow, 0, 6, 0, 5)
+	restrictLine(&s, HouseRow, 3, 6, 0, 3)
+
+	step := s.ResolveSkyscraperOptions()
+	expect := []string{"D2-[6]", "D3-[6]", "F5-[6]", "F6-[6]"} // cells seeing both F1 and D4
+	got := []string{}
+	for _, el := range step.Eliminations {
+		got = append(got, el.String())
+	}
+	if strings.Join(got, " ") != strings.Join(expect, " ") {
+		t.Errorf("got eliminations %v, expected %v", got, expect)
+	}
+	if !strings.Contains(step.Details[0], "(F1=A1 in row 1 / A4=D4 in row 4)") {
+		t.Errorf("strong links not reported: %s", step.Details[0])
+	}
+	if other := s.ResolveTwoStringKiteOptions(); other.Found() {
+		t.Errorf("skyscraper found as a 2-String Kite: %s", other.String())
+	}
+}
+
+func TestSudoku_ResolveEmptyRectangleOptions(t *testing.T) {
+	s := New(9)
+	// 2 of box 1 only possible in row 2 and column B (A2, B1, B2, C2), and strong link on 2 in column G (G2, G7)
+	for _, cell := range []Cell{{0, 0}, {0, 2}, {2, 0}, {2, 2}} {
+		s.Candidates().Eliminate(cell.Row, cell.Col, NewValueSet(2))
+	}
+	restrictLine(&s, HouseColumn, 6, 2, 1, 6)
+
+	step := s.ResolveEmptyRectangleOptions()
+	found := false
+	for _, el := range step.Eliminations {
+		found = found || el.Cell == Cell{Row: 6, Col: 1}
+	}
+	if !found {
+		t.Errorf("expected 2 to be removed from B7, got %s", step.String())
+	}
+}
+
+// singleDigitSolver returns a solver applying single digit patterns right after X-Wings, before finned fish which
+// find most of them in default order
+func singleDigitSolver(t *testing.T) Solver {
+	t.Helper()
+	reg := DefaultRegistry()
+	err := reg.SetOrder(StrategyObvious, StrategyHiddenSingletons, StrategyPointing, StrategyBoxLineReduction,
+		StrategyHiddenPairs, StrategyHiddenTriplets, StrategyNakedTriplets, StrategyNakedPairs, StrategyXWing,
+		StrategySkyscraper, StrategyTwoStringKite, StrategyTurbotFish, StrategyEmptyRectangle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Solver{Strategies: reg}
+}
+
+func TestSudoku_ResolveTurbotFishOptions_Puzzles(t *testing.T) {
+	solver := singleDigitSolver(t)
+	checkSolverTechnique(t, solver, StrategySkyscraper, "...6.....39.....41......3.......1...9....4.7.68....2...6..8..5..4.7....9.2.4.976.")
+	checkSolverTechnique(t, solver, StrategyTwoStringKite, "...........296....8...7.5.1.9.....3.13..9..6...41.89........2....5643...76.....4.")
+	checkSolverTechnique(t, solver, StrategyTurbotFish, ".....186.3..2.6..79....8...89...2.....3.1....56.7...2........7..4.89....6.....418")
+	checkSolverTechnique(t, solver, StrategyEmptyRectangle, ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......")
+}
@@ -0,0 +1,147 @@
+package sudoku
+
+// backtracker is a fast search state used to count and enumerate solutions.
+//
+// Values used by each house are kept as ValueSet bitmasks, so candidates of a cell are obtained with a few bitwise
+// operations
+type backtracker struct {
+	size          int
+	geo           *geometry
+	values        []int
+	rows, cols    []ValueSet
+	boxes         []ValueSet
+	all           ValueSet // all values of the grid
+	nbUndef       int
+	solutionFound func() bool
+}
+
+// newBacktracker returns a backtracker initialized with given sudoku values.
+// ok is false if given values conflict with each other
+func newBacktracker(s Sudoku) (bt *backtracker, ok bool) {
+	bt = &backtracker{
+		size:   s.size,
+		geo:    s.geo,
+		values: make([]int, len(s.values)),
+		rows:   make([]ValueSet, s.size),
+		cols:   make([]ValueSet, s.size),
+		boxes:  make([]ValueSet, s.size),
+		all:    fullValueSet(s.size),
+	}
+	copy(bt.values, s.values)
+	for i, v := range bt.values {
+		if v == valueUndef {
+			bt.nbUndef++
+			continue
+		}
+		r, c := i/bt.size, i%bt.size
+		if !bt.candidates(r, c).Has(v) {
+			return nil, false
+		}
+		bt.set(r, c, v)
+	}
+	return bt, true
+}
+
+func (bt *backtracker) box(row, col int) int {
+	return bt.geo.cellHouses[col+row*bt.size][HouseBox]
+}
+
+// candidates returns the values allowed in cell (row, col)
+func (bt *backtracker) candidates(row, col int) ValueSet {
+	return bt.all.Difference(bt.rows[row].Union(bt.cols[col]).Union(bt.boxes[bt.box(row, col)]))
+}
+
+func (bt *backtracker) set(row, col, value int) {
+	bt.rows[row].Add(value)
+	bt.cols[col].Add(value)
+	bt.boxes[bt.box(row, col)].Add(value)
+	bt.values[col+row*bt.size] = value
+}
+
+func (bt *backtracker) unset(row, col, value int) {
+	bt.rows[row].RemoveValue(value)
+	bt.cols[col].RemoveValue(value)
+	bt.boxes[bt.box(row, col)].RemoveValue(value)
+	bt.values[col+row*bt.size] = valueUndef
+}
+
+// search explores all completions of current values, calling solutionFound for each of them.
+// Search stops as soon as solutionFound returns false, search then returns false
+func (bt *backtracker) search() bool {
+	if bt.nbUndef == 0 {
+		return bt.solutionFound()
+	}
+	// choose the undefined cell with the fewest candidates
+	best, bestCands, bestCount := -1, ValueSet(0), bt.size+1
+	for i, v := range bt.values {
+		if v != valueUndef {
+			continue
+		}
+		cands := bt.candidates(i/bt.size, i%bt.size)
+		count := cands.Length()
+		if count < bestCount {
+			best, bestCands, bestCount = i, cands, count
+			if count <= 1 {
+				break
+			}
+		}
+	}
+	if bestCount == 0 {
+		return true // dead end, go on with other branches
+	}
+
+	row, col := best/bt.size, best%bt.size
+	bt.nbUndef--
+	defer func() { bt.nbUndef++ }()
+	for cands := bestCands; cands != 0; cands &= cands - 1 {
+		value := cands.First()
+		bt.set(row, col, value)
+		goOn := bt.search()
+		bt.unset(row, col, value)
+		if !goOn {
+			return false
+		}
+	}
+	return true
+}
+
+// CountSolutions returns the number of solutions of the receiver, counting up to limit solutions
+// (limit <= 0 counts all solutions, which may take very long for grids with few values).
+//
+// Receiver is not modified. 0 is returned if receiver values conflict with each other
+func (s Sudoku) CountSolutions(limit int) int {
+	bt, ok := newBacktracker(s)
+	if !ok {
+		return 0
+	}
+	nb := 0
+	bt.solutionFound = func() bool {
+		nb++
+		return limit <= 0 || nb < limit
+	}
+	bt.search()
+	return nb
+}
+
+// HasUniqueSolution returns true if the receiver has exactly one solution
+func (s Sudoku) HasUniqueSolution() bool {
+	return s.CountSolutions(2) == 1
+}
+
+// EachSolution calls fn for each solution of the receiver, as soon as it is found. Enumeration stops when fn
+// returns false.
+//
+// Each solution is a new Sudoku, with the receiver geometry, which can be kept or modified by fn. Receiver is not
+// modified
+func (s Sudoku) EachSolution(fn func(solution Sudoku) bool) {
+	bt, ok := newBacktracker(s)
+	if !ok {
+		return
+	}
+	bt.solutionFound = func() bool {
+		solution := NewWithBox(s.boxWidth, s.boxHeight)
+		copy(solution.values, bt.values)
+		return fn(solution)
+	}
+	bt.search()
+}
@@ -0,0 +1,96 @@
+package sudoku
+
+import "testing"
+
+func TestSudoku_CountSolutions(t *testing.T) {
+	parse := func(grid string) Sudoku {
+		s, err := Parse(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	for _, tc := range []struct {
+		name   string
+		s      Sudoku
+		limit  int
+		expect int
+	}{
+		{"Very Easy", parse(testPuzzles[1].grid), 0, 1},
+		{"Difficult 2", parse(testPuzzles[4].grid), 0, 1},
+		{"Sample", parse(testPuzzles[0].grid), 0, 58},
+		{"Sample limited", parse(testPuzzles[0].grid), 10, 10},
+		{"Empty 4x4", New(4), 0, 288},
+		{"Empty 9x9 limited", New(9), 100, 100},
+		{"Conflicting", parse("11" + testPuzzles[1].grid[2:]), 0, 0},
+	} {
+		before := tc.s.LineString()
+		if nb := tc.s.CountSolutions(tc.limit); nb != tc.expect {
+			t.Errorf("%s: CountSolutions(%d) returned %d, expected %d", tc.name, tc.limit, nb, tc.expect)
+		}
+		if tc.s.LineString() != before {
+			t.Errorf("%s: CountSolutions modified the receiver", tc.name)
+		}
+	}
+}
+
+func TestSudoku_HasUniqueSolution(t *testing.T) {
+	for i, expect := range []bool{false, true, false, false, true, true, true, true, true, true, false} {
+		s, err := Parse(testPuzzles[i].grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.HasUniqueSolution() != expect {
+			t.Errorf("%s: HasUniqueSolution should return %v", testPuzzles[i].name, expect)
+		}
+	}
+}
+
+func TestSudoku_EachSolution(t *testing.T) {
+	s, err := Parse(testPuzzles[0].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	solutions := []Sudoku{}
+	s.EachSolution(func(solution Sudoku) bool {
+		solutions = append(solutions, solution)
+		return true
+	})
+	if len(solutions) != s.CountSolutions(0) {
+		t.Fatalf("got %d solutions, expected %d", len(solutions), s.CountSolutions(0))
+	}
+	known := make(map[string]bool)
+	for _, solution := range solutions {
+		checkSolved(t, solution)
+		for i, v := range s.values {
+			if v != valueUndef && solution.values[i] != v {
+				t.Fatalf("solution does not match puzzle values:\n%s", solution.String())
+			}
+		}
+		if known[solution.LineString()] {
+			t.Fatalf("solution found twice:\n%s", solution.String())
+		}
+		known[solution.LineString()] = true
+	}
+
+	// cells distinguishing the solutions must be undefined in the puzzle
+	for i := range s.values {
+		for _, solution := range solutions[1:] {
+			if solution.values[i] != solutions[0].values[i] && s.values[i] != valueUndef {
+				t.Errorf("puzzle value at %d differs between solutions", i)
+			}
+		}
+	}
+
+	// early stop
+	nb := 0
+	s.EachSolution(func(solution Sudoku) bool {
+		nb++
+		return nb < 5
+	})
+	if nb != 5 {
+		t.Errorf("enumeration should stop after 5 solutions, got %d", nb)
+	}
+}
@@ -0,0 +1,122 @@
+package sudoku
+
+// Engine selects the solving algorithm used by a Solver
+type Engine int
+
+const (
+	// EngineLogical applies logical strategies, then tries remaining options with a recursive strategy
+	EngineLogical Engine = iota
+	// EngineDLX uses the Dancing Links exact cover search (see Sudoku.SolveDLX)
+	EngineDLX
+)
+
+// Solver solves sudokus, applying its strategies and reporting solving events to its Observer
+type Solver struct {
+	Observer   Observer  // receives solving events, nil for silent solving
+	Strategies *Registry // strategies applied by the solver, nil for DefaultRegistry strategies
+	Engine     Engine    // solving algorithm, EngineLogical by default
+	// AssumeUnique asserts solved puzzles have a unique solution, enabling uniqueness based strategies (see
+	// UniquenessStrategy). Applied to a puzzle with several solutions, these strategies may lead to a dead end
+	AssumeUnique bool
+}
+
+// Solve solves given sudoku using the solver engine.
+//
+// It returns the maximum recursion depth reached (always 0 with EngineDLX), and true if s is completed
+func (sv Solver) Solve(s *Sudoku) (int, bool) {
+	if sv.Engine == EngineDLX {
+		sv.notify(Event{Kind: EventStart, Grid: s.Clone()})
+		completed := s.SolveDLX()
+		sv.notify(Event{Kind: EventEnd, Grid: s.Clone(), Completed: completed})
+		return 0, completed
+	}
+	return sv.solve(s, 0)
+}
+
+func (sv Solver) notify(e Event) {
+	if sv.Observer != nil {
+		sv.Observer.Observe(e)
+	}
+}
+
+func (sv Solver) strategies() []Strategy {
+	strategies := defaultStrategies()
+	if sv.Strategies != nil {
+		strategies = sv.Strategies.Strategies()
+	}
+	if sv.AssumeUnique {
+		return strategies
+	}
+	res := []Strategy{}
+	for _, st := range strategies {
+		if !requiresUniqueness(st) {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+// applyFirst applies the first of given strategies making deductions on s, and returns it with its step, or false if
+// none applies
+func applyFirst(s *Sudoku, strategies []Strategy) (Strategy, Step, bool) {
+	for _, strategy := range strategies {
+		if step := strategy.Apply(s); step.Found() {
+			return strategy, step, true
+		}
+	}
+	return nil, Step{}, false
+}
+
+func (sv Solver) solve(s *Sudoku, depth int) (int, bool) {
+	sv.notify(Event{Kind: EventStart, Depth: depth, Grid: s.Clone()})
+	mdepth := depth
+
+	// loop on logical techniques, which update the candidate grid
+	grid := s.Candidates()
+	strategies := sv.strategies()
+	var options Options
+	for {
+		options = grid.Options()
+		if len(options) == 0 || grid.Contradiction() {
+			break
+		}
+		_, step, found := applyFirst(s, strategies)
+		if !found {
+			// no technique applies, exit current loop to switch to recursive strategy
+			break
+		}
+		sv.notify(Event{Kind: EventStep, Depth: depth, Step: step})
+	}
+
+	// if no options found, Sudoku is solved. If a cell has no candidate left, current attempt is a dead end
+	if len(options) == 0 || grid.Contradiction() {
+		completed := s.Completed()
+		sv.notify(Event{Kind: EventEnd, Depth: depth, Grid: s.Clone(), Completed: completed})
+		return depth, completed
+	}
+
+	// finally try remaining options with recursive strategy
+	option := options[0]
+	for _, value := range option.GetValues() {
+		sv.notify(Event{
+			Kind:       EventGuess,
+			Depth:      depth,
+			MaxDepth:   mdepth,
+			Guess:      Placement{Cell: option.cell(), Value: value},
+			Candidates: option.GetValues(),
+		})
+		s2 := s.Clone()
+		s2.SetValue(value, option.row, option.col)
+
+		ld, completed := sv.solve(&s2, depth+1)
+		if ld > mdepth {
+			mdepth = ld
+		}
+		if completed {
+			// this option/value was OK, accept result and exit successfully
+			*s = s2
+			return mdepth, true
+		}
+	}
+	return mdepth, s.Completed()
+}
@@ -0,0 +1,270 @@
+package sudoku
+
+import "fmt"
+
+// Strategy is a solving technique, applied by the Solver on the candidate options of a sudoku
+type Strategy interface {
+	// Name returns the technique name, used to identify the strategy in a Registry
+	Name() string
+	// Difficulty returns the technique difficulty weight, on the Sudoku Explainer scale (1.0 for the simplest)
+	Difficulty() float64
+	// Apply searches for deductions on the candidate grid of given sudoku (see Sudoku.Candidates), applies them
+	// (setting values on s, or eliminating candidates from its candidate grid) and returns them
+	Apply(s *Sudoku) Step
+}
+
+// Names of built-in strategies
+const (
+	StrategyObvious          = "Obvious Options"
+	StrategyHiddenSingletons = "Hidden Singletons"
+	StrategyHiddenPairs      = "Hidden Pairs"
+	StrategyHiddenTriplets   = "Hidden Triplets"
+	StrategyNakedTriplets    = "Naked Triplets"
+	StrategyNakedPairs       = "Naked Pairs"
+	StrategyPointing         = "Pointing"
+	StrategyBoxLineReduction = "Box/Line Reduction"
+	StrategyNakedQuads       = "Naked Quads"
+	StrategyHiddenQuads      = "Hidden Quads"
+	StrategyNakedSubsets     = "Naked Subsets"
+	StrategyHiddenSubsets    = "Hidden Subsets"
+	StrategyXWing            = "X-Wing"
+	StrategySwordfish        = "Swordfish"
+	StrategyJellyfish        = "Jellyfish"
+	StrategyFinnedXWing      = "Finned X-Wing"
+	StrategyFinnedSwordfish  = "Finned Swordfish"
+	StrategyFinnedJellyfish  = "Finned Jellyfish"
+	StrategySkyscraper       = "Skyscraper"
+	StrategyTwoStringKite    = "2-String Kite"
+	StrategyTurbotFish       = "Turbot Fish"
+	StrategyEmptyRectangle   = "Empty Rectangle"
+	StrategyXYWing           = "XY-Wing"
+	StrategyXYZWing          = "XYZ-Wing"
+	StrategyWWing            = "W-Wing"
+	StrategySimpleColoring   = "Simple Coloring"
+	StrategyMedusa           = "3D Medusa"
+	StrategyUniqueRectangle  = "Unique Rectangle"
+	StrategyHiddenRectangle  = "Hidden Unique Rectangle"
+	StrategyAvoidableRect    = "Avoidable Rectangle"
+	StrategyBUG              = "BUG+1"
+	StrategyXChain           = "X-Chain"
+	StrategyXYChain          = "XY-Chain"
+	StrategyAIC              = "Alternating Inference Chain"
+	StrategySueDeCoq         = "Sue de Coq"
+	StrategyALSXZ            = "ALS-XZ"
+	StrategyALSXYWing        = "ALS-XY-Wing"
+	StrategyDeathBlossom     = "Death Blossom"
+	StrategyNishio           = "Nishio Forcing Net"
+	StrategyCellForcing      = "Cell Forcing Chain"
+	StrategyUnitForcing      = "Unit Forcing Chain"
+)
+
+// UniquenessStrategy is implemented by strategies whose deductions are only valid for puzzles having a unique
+// solution. Solvers apply them only when the caller asserts uniqueness (see Solver.AssumeUnique)
+type UniquenessStrategy interface {
+	Strategy
+	RequiresUniqueness() bool
+}
+
+// requiresUniqueness returns true if given strategy deductions rely on the puzzle having a unique solution
+func requiresUniqueness(st Strategy) bool {
+	us, ok := st.(UniquenessStrategy)
+	return ok && us.RequiresUniqueness()
+}
+
+type strategy struct {
+	name       string
+	difficulty float64
+	apply      func(s *Sudoku) Step
+	unique     bool
+}
+
+// NewStrategy returns a Strategy with given name and difficulty, using apply function to search for deductions
+func NewStrategy(name string, difficulty float64, apply func(s *Sudoku) Step) Strategy {
+	return strategy{name: name, difficulty: difficulty, apply: apply}
+}
+
+// NewUniquenessStrategy returns a UniquenessStrategy with given name and difficulty, using apply function to search
+// for deductions valid for puzzles having a unique solution
+func NewUniquenessStrategy(name string, difficulty float64, apply func(s *Sudoku) Step) UniquenessStrategy {
+	return strategy{name: name, difficulty: difficulty, apply: apply, unique: true}
+}
+
+func (st strategy) Name() string {
+	return st.name
+}
+
+func (st strategy) Difficulty() float64 {
+	return st.difficulty
+}
+
+func (st strategy) Apply(s *Sudoku) Step {
+	return st.apply(s)
+}
+
+func (st strategy) RequiresUniqueness() bool {
+	return st.unique
+}
+
+// defaultStrategies returns built-in strategies in default solving order
+func defaultStrategies() []Strategy {
+	return []Strategy{
+		NewStrategy(StrategyObvious, 2.3, (*Sudoku).ResolveObviousOptions),
+		NewStrategy(StrategyHiddenSingletons, 1.5, (*Sudoku).ResolveHiddenSingletonsOptions),
+		NewStrategy(StrategyPointing, 2.6, (*Sudoku).ResolvePointingOptions),
+		NewStrategy(StrategyBoxLineReduction, 2.8, (*Sudoku).ResolveBoxLineReductionOptions),
+		NewStrategy(StrategyHiddenPairs, 3.4, (*Sudoku).ResolveHiddenPairsOptions),
+		NewStrategy(StrategyHiddenTriplets, 4.0, (*Sudoku).ResolveHiddenTripletsOptions),
+		NewStrategy(StrategyNakedTriplets, 3.6, (*Sudoku).ResolveNakedTripletOptions),
+		NewStrategy(StrategyNakedPairs, 3.0, (*Sudoku).ResolveNakedPairOptions),
+		NewStrategy(StrategyXWing, 3.2, (*Sudoku).ResolveXWingOptions),
+		NewStrategy(StrategyFinnedXWing, 3.4, (*Sudoku).ResolveFinnedXWingOptions),
+		NewStrategy(StrategySwordfish, 3.8, (*Sudoku).ResolveSwordfishOptions),
+		NewStrategy(StrategyFinnedSwordfish, 4.0, (*Sudoku).ResolveFinnedSwordfishOptions),
+		// single digit patterns after finned fish: Skyscrapers and 2-String Kites are sashimi X-Wings
+		NewStrategy(StrategySkyscraper, 4.0, (*Sudoku).ResolveSkyscraperOptions),
+		NewStrategy(StrategyTwoStringKite, 4.1, (*Sudoku).ResolveTwoStringKiteOptions),
+		NewStrategy(StrategyTurbotFish, 4.2, (*Sudoku).ResolveTurbotFishOptions),
+		NewStrategy(StrategyEmptyRectangle, 4.2, (*Sudoku).ResolveEmptyRectangleOptions),
+		NewStrategy(StrategyXYWing, 4.2, (*Sudoku).ResolveXYWingOptions),
+		NewStrategy(StrategyXYZWing, 4.4, (*Sudoku).ResolveXYZWingOptions),
+		NewStrategy(StrategyWWing, 4.4, (*Sudoku).ResolveWWingOptions),
+		NewUniquenessStrategy(StrategyUniqueRectangle, 4.5, (*Sudoku).ResolveUniqueRectangleOptions),
+		NewUniquenessStrategy(StrategyHiddenRectangle, 4.6, (*Sudoku).ResolveHiddenUniqueRectangleOptions),
+		NewUniquenessStrategy(StrategyAvoidableRect, 4.5, (*Sudoku).ResolveAvoidableRectangleOptions),
+		NewUniquenessStrategy(StrategyBUG, 5.6, (*Sudoku).ResolveBUGOptions),
+		NewStrategy(StrategySimpleColoring, 4.5, (*Sudoku).ResolveSimpleColoringOptions),
+		NewStrategy(StrategyMedusa, 5.5, (*Sudoku).Resolve3DMedusaOptions),
+		NewStrategy(StrategyNakedQuads, 5.0, (*Sudoku).ResolveNakedQuadOptions),
+		NewStrategy(StrategyJellyfish, 5.2, (*Sudoku).ResolveJellyfishOptions),
+		NewStrategy(StrategyFinnedJellyfish, 5.4, (*Sudoku).ResolveFinnedJellyfishOptions),
+		NewStrategy(StrategyHiddenQuads, 5.4, (*Sudoku).ResolveHiddenQuadsOptions),
+		NewStrategy(StrategyNakedSubsets, 5.6, (*Sudoku).ResolveNakedSubsetsOptions),
+		NewStrategy(StrategyHiddenSubsets, 5.8, (*Sudoku).ResolveHiddenSubsetsOptions),
+		NewStrategy(StrategySueDeCoq, 6.0, (*Sudoku).ResolveSueDeCoqOptions),
+		NewStrategy(StrategyXChain, 6.5, (*Sudoku).ResolveXChainOptions),
+		NewStrategy(StrategyXYChain, 6.6, (*Sudoku).ResolveXYChainOptions),
+		NewStrategy(StrategyAIC, 7.0, (*Sudoku).ResolveAICOptions),
+		NewStrategy(StrategyALSXZ, 7.5, (*Sudoku).ResolveALSXZOptions),
+		NewStrategy(StrategyALSXYWing, 8.0, (*Sudoku).ResolveALSXYWingOptions),
+		NewStrategy(StrategyDeathBlossom, 8.5, (*Sudoku).ResolveDeathBlossomOptions),
+		NewStrategy(StrategyNishio, 8.8, (*Sudoku).ResolveNishioOptions),
+		NewStrategy(StrategyCellForcing, 9.0, (*Sudoku).ResolveCellForcingChainOptions),
+		NewStrategy(StrategyUnitForcing, 9.2, (*Sudoku).ResolveUnitForcingChainOptions),
+	}
+}
+
+// Registry holds an ordered list of strategies, each of them being enabled or disabled
+type Registry struct {
+	strategies []Strategy
+	disabled   map[string]bool
+}
+
+// NewRegistry returns a Registry holding given strategies, all enabled, in given order
+func NewRegistry(strategies ...Strategy) *Registry {
+	return &Registry{
+		strategies: append([]Strategy{}, strategies...),
+		disabled:   make(map[string]bool),
+	}
+}
+
+// DefaultRegistry returns a new Registry holding all built-in strategies in default order
+func DefaultRegistry() *Registry {
+	return NewRegistry(defaultStrategies()...)
+}
+
+func (r *Registry) index(name string) int {
+	for i, st := range r.strategies {
+		if st.Name() == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Register appends given strategy (enabled) at the end of the receiver list.
+//
+// An error is returned if a strategy with the same name is already registered
+func (r *Registry) Register(st Strategy) error {
+	if r.index(st.Name()) >= 0 {
+		return fmt.Errorf("sudoku: strategy %q already registered", st.Name())
+	}
+	r.strategies = append(r.strategies, st)
+	return nil
+}
+
+// Get returns the registered strategy with given name
+func (r *Registry) Get(name string) (Strategy, bool) {
+	i := r.index(name)
+	if i < 0 {
+		return nil, false
+	}
+	return r.strategies[i], true
+}
+
+// Enable enables strategy with given name
+func (r *Registry) Enable(name string) error {
+	if r.index(name) < 0 {
+		return fmt.Errorf("sudoku: unknown strategy %q", name)
+	}
+	delete(r.disabled, name)
+	return nil
+}
+
+// Disable disables strategy with given name, so it is no longer used by solvers
+func (r *Registry) Disable(name string) error {
+	if r.index(name) < 0 {
+		return fmt.Errorf("sudoku: unknown strategy %q", name)
+	}
+	r.disabled[name] = true
+	return nil
+}
+
+// Enabled returns true if strategy with given name is registered and enabled
+func (r *Registry) Enabled(name string) bool {
+	return r.index(name) >= 0 && !r.disabled[name]
+}
+
+// SetOrder moves strategies with given names at the beginning of the receiver list, in given order.
+// Other strategies keep their relative order after them
+func (r *Registry) SetOrder(names ...string) error {
+	ordered := make([]Strategy, 0, len(r.strategies))
+	moved := make(map[string]bool)
+	for _, name := range names {
+		st, found := r.Get(name)
+		if !found {
+			return fmt.Errorf("sudoku: unknown strategy %q", name)
+		}
+		if moved[name] {
+			return fmt.Errorf("sudoku: strategy %q listed twice", name)
+		}
+		moved[name] = true
+		ordered = append(ordered, st)
+	}
+	for _, st := range r.strategies {
+		if !moved[st.Name()] {
+			ordered = append(ordered, st)
+		}
+	}
+	r.strategies = ordered
+	return nil
+}
+
+// Names returns the names of all registered strategies (enabled or not), in order
+func (r *Registry) Names() []string {
+	res := make([]string, len(r.strategies))
+	for i, st := range r.strategies {
+		res[i] = st.Name()
+	}
+	return res
+}
+
+// Strategies returns the enabled strategies, in order
+func (r *Registry) Strategies() []Strategy {
+	res := []Strategy{}
+	for _, st := range r.strategies {
+		if !r.disabled[st.Name()] {
+			res = append(res, st)
+		}
+	}
+	return res
+}
@@ -0,0 +1,193 @@
+package sudoku
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := DefaultRegistry()
+	if err := r.Register(NewStrategy(StrategyObvious, 1, nil)); err == nil {
+		t.Errorf("registering a duplicate strategy should fail")
+	}
+	if err := r.Disable("Unknown"); err == nil {
+		t.Errorf("disabling an unknown strategy should fail")
+	}
+	if err := r.Disable(StrategyHiddenPairs); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetOrder(StrategyNakedPairs, StrategyHiddenSingletons); err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{StrategyNakedPairs, StrategyHiddenSingletons}
+	expectEnabled := []string{StrategyNakedPairs, StrategyHiddenSingletons}
+	for _, name := range DefaultRegistry().Names() {
+		if name == StrategyNakedPairs || name == StrategyHiddenSingletons {
+			continue
+		}
+		expect = append(expect, name)
+		if name != StrategyHiddenPairs {
+			expectEnabled = append(expectEnabled, name)
+		}
+	}
+	if !reflect.DeepEqual(r.Names(), expect) {
+		t.Errorf("got names %v, expected %v", r.Names(), expect)
+	}
+	enabled := []string{}
+	for _, st := range r.Strategies() {
+		enabled = append(enabled, st.Name())
+	}
+	if !reflect.DeepEqual(enabled, expectEnabled) {
+		t.Errorf("got enabled strategies %v, expected %v", enabled, expectEnabled)
+	}
+	if err := r.Enable(StrategyHiddenPairs); err != nil || !r.Enabled(StrategyHiddenPairs) {
+		t.Errorf("strategy %q should be enabled (err: %v)", StrategyHiddenPairs, err)
+	}
+}
+
+func TestSolver_CustomStrategy(t *testing.T) {
+	s, err := Parse(testPuzzles[1].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// custom strategy setting only the first obvious option found
+	nbCalls := 0
+	firstObvious := NewStrategy("First Obvious", 2.3, func(s *Sudoku) Step {
+		nbCalls++
+		step := Step{Technique: "First Obvious"}
+		for _, option := range s.Candidates().Options() {
+			if option.Length() == 1 {
+				s.place(&step, option.cell(), option.GetValues()[0])
+				step.Details = append(step.Details, option.String())
+				break
+			}
+		}
+		return step
+	})
+	r := NewRegistry(firstObvious)
+	techniques := make(map[string]int)
+	observer := ObserverFunc(func(e Event) {
+		if e.Kind == EventStep {
+			techniques[e.Step.Technique]++
+		}
+	})
+	if _, completed := (Solver{Observer: observer, Strategies: r}).Solve(&s); !completed {
+		t.Fatalf("sudoku should be completed:\n%s", s.String())
+	}
+	checkSolved(t, s)
+	if nbCalls == 0 || len(techniques) != 1 || techniques["First Obvious"] == 0 {
+		t.Errorf("unexpected techniques used: %v", techniques)
+	}
+}
+
+func TestSudoku_ResolveHiddenSingletonsOptions_Houses(t *testing.T) {
+	s, err := Parse(testPuzzles[2].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	houseKinds := make(map[string]bool)
+	observer := ObserverFunc(func(e Event) {
+		if e.Kind != EventStep || e.Step.Technique != StrategyHiddenSingletons {
+			return
+		}
+		for _, detail := range e.Step.Details {
+			for _, kind := range []string{"box", "row", "column"} {
+				if strings.Contains(detail, " in "+kind+" ") {
+					houseKinds[kind] = true
+				}
+			}
+		}
+	})
+	r := NewRegistry(defaultStrategies()[:2]...)
+	Solver{Observer: observer, Strategies: r}.Solve(&s)
+	for _, kind := range []string{"box", "row", "column"} {
+		if !houseKinds[kind] {
+			t.Errorf("no hidden singleton found in a %s", kind)
+		}
+	}
+}
+
+func TestSudoku_ResolveHiddenSingletonsOptions_NakedSingle(t *testing.T) {
+	s := New(9)
+	// A1 can only hold 1, and B1 is the other cell of row 1 having 1 as candidate: 1 is not a hidden single in B1
+	restrictCell(&s, 0, 0, 1)
+	for c := 2; c < 9; c++ {
+		s.Candidates().Eliminate(0, c, NewValueSet(1))
+	}
+	for _, p := range s.ResolveHiddenSingletonsOptions().Placements {
+		if p.Cell == (Cell{0, 1}) {
+			t.Errorf("unexpected placement %s", p.String())
+		}
+	}
+}
+
+// checkTechnique solves given unique solution puzzles with the default strategies, and checks that technique made at
+// least one deduction, and that every deduction made before guessing agrees with the puzzle solution
+func checkTechnique(t *testing.T, technique string, grids ...string) {
+	t.Helper()
+	checkSolverTechnique(t, Solver{}, technique, grids...)
+}
+
+// checkSolverTechnique is checkTechnique using given solver settings
+func checkSolverTechnique(t *testing.T, solver Solver, technique string, grids ...string) {
+	t.Helper()
+	nbFound := 0
+	for _, grid := range grids {
+		s, err := Parse(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		solution := s.Clone()
+		if !solution.SolveDLX() {
+			t.Fatalf("puzzle has no solution: %s", grid)
+		}
+		observer := ObserverFunc(func(e Event) {
+			if e.Kind != EventStep || e.Depth > 0 {
+				return
+			}
+			if e.Step.Technique == technique {
+				nbFound++
+			}
+			for _, p := range e.Step.Placements {
+				if solution.GetValue(p.Row, p.Col) != p.Value {
+					t.Errorf("%s: wrong placement %s in %s", e.Step.Technique, p.String(), e.Step.String())
+				}
+			}
+			for _, el := range e.Step.Eliminations {
+				if NewValueSet(el.Values...).Has(solution.GetValue(el.Row, el.Col)) {
+					t.Errorf("%s: wrong elimination %s in %s", e.Step.Technique, el.String(), e.Step.String())
+				}
+			}
+		})
+		solver.Observer = observer
+		solver.Solve(&s)
+	}
+	if nbFound == 0 {
+		t.Errorf("technique %q was not used", technique)
+	}
+}
+
+// testHardPuzzles are well known hard 9x9 puzzles, with a unique solution
+var testHardPuzzles = []string{
+	"1.......2.9.4...5...6...7...5.9.3.......7.......85..4.7.....6...3...9.8...2.....1", // Easter Monster
+	"1....7.9..3..2...8..96..5....53..9...1..8...26....4...3......1..4......7..7...3..", // AI Escargot
+	"8..........36......7..9.2...5...7.......457.....1...3...1....68..85...1..9....4..", // Inkala
+}
+
+// uniqueTestGrids returns the test puzzles having a unique solution
+func uniqueTestGrids(t *testing.T) []string {
+	t.Helper()
+	res := []string{}
+	for _, tc := range testPuzzles {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.HasUniqueSolution() {
+			res = append(res, tc.grid)
+		}
+	}
+	return append(res, testHardPuzzles...)
+}
@@ -0,0 +1,180 @@
+package sudoku
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// maxSubsetSize returns the largest subset size worth searching on the receiver grid: a house with a naked subset of
+// size k also holds a hidden subset of size n-k on its remaining cells, so searching up to n/2 finds all of them
+func (s Sudoku) maxSubsetSize() int {
+	return s.size / 2
+}
+
+// forEachSubset calls fn for each combination of size masks (given by their indexes) whose union holds exactly size
+// elements. Combinations are explored in ascending index order, and pruned as soon as their union grows too large
+func forEachSubset(masks []uint32, size int, fn func(chosen []int, union uint32)) {
+	chosen := make([]int, 0, size)
+	var search func(start int, union uint32)
+	search = func(start int, union uint32) {
+		if len(chosen) == size {
+			if bits.OnesCount32(union) == size {
+				fn(chosen, union)
+			}
+			return
+		}
+		for i := start; i <= len(masks)-(size-len(chosen)); i++ {
+			u := union | masks[i]
+			if bits.OnesCount32(u) > size {
+				continue
+			}
+			chosen = append(chosen, i)
+			search(i+1, u)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	search(0, 0)
+}
+
+// resolveNakedSubsets searches each house for size cells whose candidates hold only size values altogether: these
+// values must be set in these cells, so they can be removed from the other cells of the house.
+//
+// All subset shapes are found, such as [1, 2] / [2, 3] / [1, 3] or [1, 2, 3] / [1, 2, 3] / [1, 2]
+func (s *Sudoku) resolveNakedSubsets(step *Step, size int) {
+	grid := s.Candidates()
+	for _, house := range s.Houses() {
+		// unsolved cells of the house, and candidates of those which can be part of a subset
+		cells, masks := []Cell{}, []uint32{}
+		for _, cell := range s.HouseCells(house) {
+			if length := grid.Get(cell.Row, cell.Col).Length(); length >= 2 && length <= size {
+				cells = append(cells, cell)
+				masks = append(masks, uint32(grid.Get(cell.Row, cell.Col)))
+			}
+		}
+		forEachSubset(masks, size, func(chosen []int, union uint32) {
+			subset := ValueSet(union)
+			members := make(map[Cell]bool)
+			subsetOptions := []string{}
+			for _, i := range chosen {
+				members[cells[i]] = true
+				subsetOptions = append(subsetOptions, grid.option(cells[i]).String())
+			}
+			for _, cell := range s.HouseCells(house) {
+				if members[cell] || grid.Get(cell.Row, cell.Col).Intersection(subset) == 0 {
+					continue
+				}
+				option := grid.option(cell)
+				removed := option.option.Intersection(subset)
+				s.eliminate(step, cell, subset)
+				step.Details = append(step.Details, fmt.Sprintf("%s from %s in %s (naked %s)", removed.String(), option.String(), house.String(), strings.Join(subsetOptions, " / ")))
+			}
+		})
+	}
+}
+
+// resolveHiddenSubsets searches each house for size values whose candidate cells are only size cells altogether:
+// these cells must hold these values, so their other candidates can be removed
+func (s *Sudoku) resolveHiddenSubsets(step *Step, size int) {
+	grid := s.Candidates()
+	for _, house := range s.Houses() {
+		// candidate cells of each value, as a mask of cell indexes within the house
+		houseCells := s.HouseCells(house)
+		values, masks := []int{}, []uint32{}
+		for v := 1; v <= s.size; v++ {
+			var mask uint32
+			for i, cell := range houseCells {
+				if grid.Has(cell.Row, cell.Col, v) {
+					mask |= 1 << i
+				}
+			}
+			if nb := bits.OnesCount32(mask); nb >= 2 && nb <= size {
+				values = append(values, v)
+				masks = append(masks, mask)
+			}
+		}
+		forEachSubset(masks, size, func(chosen []int, union uint32) {
+			subset := NewValueSet()
+			for _, i := range chosen {
+				subset.Add(values[i])
+			}
+			actualOptions := []string{}
+			for i, cell := range houseCells {
+				if union&(1<<i) == 0 || grid.Get(cell.Row, cell.Col).Difference(subset) == 0 {
+					continue
+				}
+				actualOptions = append(actualOptions, grid.option(cell).String())
+				s.eliminate(step, cell, grid.Get(cell.Row, cell.Col).Difference(subset))
+			}
+			if len(actualOptions) > 0 {
+				step.Details = append(step.Details, fmt.Sprintf("%s from %s in %s", subset.String(), strings.Join(actualOptions, " / "), house.String()))
+			}
+		})
+	}
+}
+
+// ResolveNakedPairOptions based on https://sudoku.com/fr/regles-du-sudoku/paires-nues
+func (s *Sudoku) ResolveNakedPairOptions() Step {
+	step := Step{Technique: "Naked Pairs"}
+	s.resolveNakedSubsets(&step, 2)
+	return step
+}
+
+// ResolveNakedTripletOptions based on https://sudoku.com/fr/regles-du-sudoku/triplets-nus
+func (s *Sudoku) ResolveNakedTripletOptions() Step {
+	step := Step{Technique: "Naked Triplets"}
+	s.resolveNakedSubsets(&step, 3)
+	return step
+}
+
+// ResolveNakedQuadOptions based on https://www.sudokuwiki.org/Naked_Candidates#NQ
+func (s *Sudoku) ResolveNakedQuadOptions() Step {
+	step := Step{Technique: "Naked Quads"}
+	if s.maxSubsetSize() >= 4 {
+		s.resolveNakedSubsets(&step, 4)
+	}
+	return step
+}
+
+// ResolveNakedSubsetsOptions searches naked subsets larger than quads, up to half the grid size (only relevant for
+// grids larger than 9x9)
+func (s *Sudoku) ResolveNakedSubsetsOptions() Step {
+	step := Step{Technique: "Naked Subsets"}
+	for size := 5; size <= s.maxSubsetSize() && !step.Found(); size++ {
+		s.resolveNakedSubsets(&step, size)
+	}
+	return step
+}
+
+// ResolveHiddenPairsOptions based on https://sudoku.com/fr/regles-du-sudoku/paires-cachees/
+func (s *Sudoku) ResolveHiddenPairsOptions() Step {
+	step := Step{Technique: "Hidden Pairs"}
+	s.resolveHiddenSubsets(&step, 2)
+	return step
+}
+
+// ResolveHiddenTripletsOptions based on https://sudoku.com/fr/regles-du-sudoku/triplets-caches/
+func (s *Sudoku) ResolveHiddenTripletsOptions() Step {
+	step := Step{Technique: "Hidden Triplets"}
+	s.resolveHiddenSubsets(&step, 3)
+	return step
+}
+
+// ResolveHiddenQuadsOptions based on https://www.sudokuwiki.org/Hidden_Candidates#HQ
+func (s *Sudoku) ResolveHiddenQuadsOptions() Step {
+	step := Step{Technique: "Hidden Quads"}
+	if s.maxSubsetSize() >= 4 {
+		s.resolveHiddenSubsets(&step, 4)
+	}
+	return step
+}
+
+// ResolveHiddenSubsetsOptions searches hidden subsets larger than quads, up to half the grid size (only relevant for
+// grids larger than 9x9)
+func (s *Sudoku) ResolveHiddenSubsetsOptions() Step {
+	step := Step{Technique: "Hidden Subsets"}
+	for size := 5; size <= s.maxSubsetSize() && !step.Found(); size++ {
+		s.resolveHiddenSubsets(&step, size)
+	}
+	return step
+}
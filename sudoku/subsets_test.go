@@ -0,0 +1,82 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSudoku_ResolveNakedTripletOptions_Shapes(t *testing.T) {
+	s := New(9)
+	grid := s.Candidates()
+	// naked triplet [1, 2] / [2, 3] / [1, 3] in row 1 (and box 1)
+	grid.Eliminate(0, 0, fullValueSet(9).Difference(NewValueSet(1, 2)))
+	grid.Eliminate(0, 1, fullValueSet(9).Difference(NewValueSet(2, 3)))
+	grid.Eliminate(0, 2, fullValueSet(9).Difference(NewValueSet(1, 3)))
+
+	step := s.ResolveNakedTripletOptions()
+	if !step.Found() {
+		t.Fatal("naked triplet not found")
+	}
+	for _, cell := range []Cell{{0, 3}, {0, 8}, {1, 0}, {2, 2}} {
+		if got := grid.Get(cell.Row, cell.Col); got.Intersection(NewValueSet(1, 2, 3)) != 0 {
+			t.Errorf("%s: expected [1, 2, 3] to be removed, got %s", cell.String(), got.String())
+		}
+	}
+	for _, cell := range []Cell{{1, 3}, {3, 0}} {
+		if got := grid.Get(cell.Row, cell.Col); got != fullValueSet(9) {
+			t.Errorf("%s: expected no elimination, got %s", cell.String(), got.String())
+		}
+	}
+}
+
+func TestSudoku_ResolveHiddenQuadsOptions(t *testing.T) {
+	s := New(9)
+	grid := s.Candidates()
+	// values 1 to 4 of row 5 are only possible in A5, C5, E5 and G5
+	quad := NewValueSet(1, 2, 3, 4)
+	for c := 0; c < 9; c++ {
+		if c%2 == 1 || c == 8 {
+			grid.Eliminate(4, c, quad)
+		}
+	}
+
+	step := s.ResolveHiddenQuadsOptions()
+	if !step.Found() {
+		t.Fatal("hidden quad not found")
+	}
+	for c := 0; c < 8; c += 2 {
+		if got := grid.Get(4, c); got != quad {
+			t.Errorf("%s: expected %s, got %s", Cell{4, c}.String(), quad.String(), got.String())
+		}
+	}
+	expect := []string{"[1, 2, 3, 4] from A5[1, 2, 3, 4, 5, 6, 7, 8, 9] / C5[1, 2, 3, 4, 5, 6, 7, 8, 9] / E5[1, 2, 3, 4, 5, 6, 7, 8, 9] / G5[1, 2, 3, 4, 5, 6, 7, 8, 9] in row 5"}
+	if !reflect.DeepEqual(step.Details, expect) {
+		t.Errorf("got details %v, expected %v", step.Details, expect)
+	}
+}
+
+func TestSudoku_ResolveNakedSubsetsOptions(t *testing.T) {
+	s := New(16)
+	grid := s.Candidates()
+	// naked subset of 5 values in the first 5 cells of row 1
+	subset := NewValueSet(1, 2, 3, 4, 5)
+	for c := 0; c < 5; c++ {
+		grid.Eliminate(0, c, fullValueSet(16).Difference(subset).Union(NewValueSet(c+1)))
+	}
+
+	s9 := New(9)
+	if step := s9.ResolveNakedSubsetsOptions(); step.Found() {
+		t.Errorf("no subset larger than quads expected on 9x9 grids, got %s", step.String())
+	}
+	step := s.ResolveNakedSubsetsOptions()
+	if !step.Found() {
+		t.Fatal("naked subset not found")
+	}
+	if got := grid.Get(0, 15); got.Intersection(subset) != 0 {
+		t.Errorf("expected %s to be removed from P1, got %s", subset.String(), got.String())
+	}
+}
+
+func TestSudoku_ResolveNakedPairOptions(t *testing.T) {
+	checkTechnique(t, StrategyNakedPairs, uniqueTestGrids(t)...)
+}
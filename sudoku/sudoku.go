@@ -6,8 +6,12 @@ import (
 )
 
 type Sudoku struct {
-	size   int
-	values []int
+	size       int
+	boxWidth   int
+	boxHeight  int
+	values     []int
+	geo        *geometry      // house and peer tables, shared by sudokus with the same geometry
+	candidates *CandidateGrid // computed on demand by Candidates()
 }
 
 const (
@@ -15,15 +19,75 @@ const (
 	valueError int = -1
 )
 
+// valueSymbols gives the character used to display each value (value v is displayed as valueSymbols[v-1])
+const valueSymbols = "123456789ABCDEFGHIJKLMNOP"
+
+// maxSize is the largest supported grid size (one symbol per value)
+const maxSize = len(valueSymbols)
+
+// New returns an empty Sudoku of given size.
+//
+// Box geometry is derived from size: boxes are square when size is a perfect square (4, 9, 16, 25),
+// otherwise boxes are as square as possible and wider than high (3x2 for size 6, 4x3 for size 12).
+//
+// New panics if size can't be split in such boxes of at least 2 rows and 2 columns (see ValidateBox), as for prime
+// sizes
 func New(size int) Sudoku {
+	width, height := boxDimensions(size)
+	return NewWithBox(width, height)
+}
+
+// NewWithBox returns an empty Sudoku whose boxes are boxWidth columns wide and boxHeight rows high.
+//
+// Grid size is boxWidth*boxHeight (NewWithBox(3, 2) returns a 6x6 grid made of six 3x2 boxes).
+//
+// NewWithBox panics if the box dimensions are rejected by ValidateBox
+func NewWithBox(boxWidth, boxHeight int) Sudoku {
+	if err := ValidateBox(boxWidth, boxHeight); err != nil {
+		panic(err)
+	}
+	size := boxWidth * boxHeight
 	s := Sudoku{
-		size:   size,
-		values: make([]int, size*size),
+		size:      size,
+		boxWidth:  boxWidth,
+		boxHeight: boxHeight,
+		values:    make([]int, size*size),
+		geo:       getGeometry(boxWidth, boxHeight),
 	}
 
 	return s
 }
 
+// ValidateBox returns an error if a grid can't be made of boxes boxWidth columns wide and boxHeight rows high: both
+// dimensions must be at least 2, and the grid size (their product) at most 25, the number of value symbols
+func ValidateBox(boxWidth, boxHeight int) error {
+	if boxWidth < 2 || boxHeight < 2 || boxWidth*boxHeight > maxSize {
+		return fmt.Errorf("sudoku: unsupported %dx%d boxes", boxWidth, boxHeight)
+	}
+	return nil
+}
+
+// boxDimensions returns the most square box geometry (width >= height) for given grid size
+func boxDimensions(size int) (width, height int) {
+	height = 1
+	for h := 1; h*h <= size; h++ {
+		if size%h == 0 {
+			height = h
+		}
+	}
+	return size / height, height
+}
+
+// Size returns the number of rows (and columns) of the receiver grid
+func (s Sudoku) Size() int {
+	return s.size
+}
+
+// BoxSize returns the width (number of columns) and height (number of rows) of the receiver boxes
+func (s Sudoku) BoxSize() (width, height int) {
+	return s.boxWidth, s.boxHeight
+}
+
 // Clone returns a deep copy of receiver
 func (s Sudoku) Clone() Sudoku {
 	nsv := make([]int, len(s.values))
@@ -31,27 +95,45 @@ func (s Sudoku) Clone() Sudoku {
 		nsv[i] = value
 	}
 
-	return Sudoku{
-		size:   s.size,
-		values: nsv,
+	res := Sudoku{
+		size:      s.size,
+		boxWidth:  s.boxWidth,
+		boxHeight: s.boxHeight,
+		values:    nsv,
+		geo:       s.geo,
+	}
+	if s.candidates != nil {
+		res.candidates = s.candidates.Clone()
 	}
+	return res
 }
 
 func (s *Sudoku) SetValue(value, row, col int) {
-	if !(row >= 0 && row <= s.size) {
+	if !(row >= 0 && row < s.size) {
 		return
 	}
-	if !(col >= 0 && col <= s.size) {
+	if !(col >= 0 && col < s.size) {
 		return
 	}
+	previous := s.values[col+row*s.size]
 	s.values[col+row*s.size] = value
+	if s.candidates == nil {
+		return
+	}
+	if value == valueUndef {
+		if previous != valueUndef {
+			s.candidates.clear(*s, row, col, previous)
+		}
+		return
+	}
+	s.candidates.place(value, row, col)
 }
 
 func (s Sudoku) GetValue(row, col int) int {
-	if !(row >= 0 && row <= s.size) {
+	if !(row >= 0 && row < s.size) {
 		return valueError
 	}
-	if !(col >= 0 && col <= s.size) {
+	if !(col >= 0 && col < s.size) {
 		return valueError
 	}
 	return s.values[col+row*s.size]
@@ -61,64 +143,54 @@ func (s Sudoku) getValue(row, col int) int {
 	return s.values[col+row*s.size]
 }
 
-func (s Sudoku) getSubScareBounds(row, col int) (rowMin, rowMax, colMin, colMax int) {
-	rowMin = row / 3 * 3
-	colMin = col / 3 * 3
-	return rowMin, rowMin + 2, colMin, colMin + 2
-}
-
 // IsValid returns true if value at position (row, col) is legit
 func (s Sudoku) IsValid(value, row, col int) bool {
-	// check for row
-	for i := 0; i < s.size; i++ {
-		if i == row {
-			continue
-		}
-		if value == s.getValue(i, col) {
-			return false
-		}
-	}
-	// check for col
-	for j := 0; j < s.size; j++ {
-		if j == col {
-			continue
-		}
-		if value == s.getValue(row, j) {
+	for _, peer := range s.Peers(row, col) {
+		if value == s.getValue(peer.Row, peer.Col) {
 			return false
 		}
 	}
-	// check for subScare
-	rMin, rMax, cMin, cMax := s.getSubScareBounds(row, col)
-	for r := rMin; r <= rMax; r++ {
-		for c := cMin; c <= cMax; c++ {
-			if r == row && c == col {
-				continue
-			}
-			if value == s.getValue(r, c) {
-				return false
-			}
-		}
-	}
 	return true
 }
 
+// valueString returns the display symbol of given value
+func valueString(value int) string {
+	if value < 1 || value > maxSize {
+		return "?"
+	}
+	return valueSymbols[value-1 : value]
+}
+
 func (s Sudoku) String() string {
 	res := strings.Builder{}
-	res.WriteString("       A  B  C  .  D  E  F  .  G  H  I\n")
+	// header line with column names, and separator line between boxes
+	header := strings.Builder{}
+	separator := strings.Builder{}
+	header.WriteString("      ")
+	separator.WriteString("   -  ")
+	for c := 0; c < s.size; c++ {
+		if c > 0 && c%s.boxWidth == 0 {
+			header.WriteString(" . ")
+			separator.WriteString("-+-")
+		}
+		header.WriteString(fmt.Sprintf(" %c ", 'A'+c))
+		separator.WriteString("---")
+	}
+	res.WriteString(strings.TrimRight(header.String(), " ") + "\n")
 	for r := 0; r < s.size; r++ {
-		if r > 0 && r%3 == 0 {
-			res.WriteString("   -  ----------+-----------+----------\n")
+		if r > 0 && r%s.boxHeight == 0 {
+			res.WriteString(separator.String() + "\n")
 		}
-		res.WriteString(fmt.Sprintf("   %d  ", r+1))
+		res.WriteString(fmt.Sprintf("%4d  ", r+1))
 		for c := 0; c < s.size; c++ {
-			if c > 0 && c%3 == 0 {
+			if c > 0 && c%s.boxWidth == 0 {
 				res.WriteString(" | ")
 			}
 			v := s.getValue(r, c)
 			if v == valueUndef {
 				res.WriteString("   ")
 			} else {
-				res.WriteString(fmt.Sprintf(" %d ", v))
+				res.WriteString(" " + valueString(v) + " ")
 			}
 		}
 		res.WriteString("\n")
@@ -126,15 +198,26 @@ func (s Sudoku) String() string {
 	return res.String()
 }
 
+// LineString returns the receiver in one-line format (one character per cell, row by row, '.' for undefined values)
+func (s Sudoku) LineString() string {
+	res := strings.Builder{}
+	for _, v := range s.values {
+		if v == valueUndef {
+			res.WriteString(".")
+		} else {
+			res.WriteString(valueString(v))
+		}
+	}
+	return res.String()
+}
+
 // GetValid returns a ValueSet of all possibles values at given position
 func (s Sudoku) GetValid(row, col int) ValueSet {
-	res := make(ValueSet)
-	for _, v := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9} {
-		if s.IsValid(v, row, col) {
-			res[v] = struct{}{}
-		}
+	var used ValueSet
+	for _, peer := range s.Peers(row, col) {
+		used.Add(s.getValue(peer.Row, peer.Col))
 	}
-	return res
+	return fullValueSet(s.size).Difference(used)
 }
 
 // GetAllOptions returns a slice of Option, giving, for each undef position, all possible values
@@ -152,7 +235,7 @@ func (s Sudoku) GetAllOptions() Options {
 				col:    c,
 				option: s.GetValid(r, c),
 			}
-			if len(opt.option) == 0 {
+			if opt.option == 0 {
 				continue
 			}
 			res = append(res, opt)
@@ -173,470 +256,50 @@ func (s Sudoku) Completed() bool {
 	return true
 }
 
+// Solve solves the receiver silently, using logical techniques then a recursive strategy.
+//
+// It returns the maximum recursion depth reached, and true if the receiver is completed.
+// Use a Solver with an Observer to get the solving trace
 func (s *Sudoku) Solve(depth int) (int, bool) {
-	fmt.Printf(s.String())
-	mdepth := depth
-	var options Options
-	// loop on obvious solutions
-	//fmt.Printf("(depth = %d) Found %d options\n", depth, len(options))
-
-	// get all available options
-	options = s.GetAllOptions()
-	for len(options) > 0 {
-
-		nbObvious, result := s.ResolveObviousOptions(options)
-		if nbObvious > 0 { // some obvious solution found, update options
-			fmt.Println(result)
-			options = s.GetAllOptions()
-			continue
-		}
-
-		nbHiddenSingletons, result := s.ResolveHiddenSingletonsOptions(options)
-		if nbHiddenSingletons > 0 {
-			fmt.Println(result)
-			options = s.GetAllOptions()
-			continue
-		}
-
-		nbHiddenPairs, result := s.ResolveHiddenPairsOptions(options)
-		if nbHiddenPairs > 0 {
-			fmt.Println(result)
-			continue
-		}
-
-		nbHiddenTriplets, result := s.ResolveHiddenTripletsOptions(options)
-		if nbHiddenTriplets > 0 {
-			fmt.Println(result)
-			continue
-		}
-
-		nakedTriplets, result := s.ResolveNakedTripletOptions(options)
-		if nakedTriplets > 0 {
-			fmt.Println(result)
-			continue
-		}
-
-		nakedPairs, result := s.ResolveNakedPairOptions(options)
-		if nakedPairs > 0 {
-			fmt.Println(result)
-			continue
-		}
-
-		// no obvious solution found, exit current loop to switch to another strategy
-		if nbObvious == 0 {
-			break
-		}
-	}
-
-	// if no options found, Sudoku is solved
-	if len(options) == 0 {
-		completed := s.Completed()
-		fmt.Printf("No other options, sudoku completed=%v\n%s", completed, s.String())
-		return depth, completed
-	}
-
-	// finally try remaining options with recursive strategy
-	option := options[0]
-	for _, value := range option.GetValues() {
-		fmt.Printf("(depth = %d/%d) Set possible %d of %s\n", depth, mdepth, value, option.String())
-		s2 := s.Clone()
-		s2.SetValue(value, option.row, option.col)
-
-		ld, completed := s2.Solve(depth + 1)
-		if ld > mdepth {
-			mdepth = ld
-		}
-		if completed {
-			// this option/value was OK, accept result and exit successfully
-			s.values = s2.values
-			return mdepth, true
-		}
-	}
-	return mdepth, s.Completed()
+	return Solver{}.solve(s, depth)
 }
 
 // ResolveObviousOptions sets all obvious option (that is option with only 1 possible value)
-func (s *Sudoku) ResolveObviousOptions(options Options) (int, string) {
-	obvOpts := Options{}
-	res := "Obvious Options:"
-	for _, option := range options {
+func (s *Sudoku) ResolveObviousOptions() Step {
+	step := Step{Technique: "Obvious Options"}
+	for _, option := range s.Candidates().Options() {
 		if option.Length() != 1 {
 			continue
 		}
-		obvOpts = append(obvOpts, option)
-		s.SetValue(option.GetValues()[0], option.row, option.col)
-	}
-	nbObvious := len(obvOpts)
-	if nbObvious == 0 {
-		res += " None"
-	} else {
-		list := make([]string, nbObvious)
-		for i, opt := range obvOpts {
-			list[i] = fmt.Sprintf("%s", opt.String())
-		}
-		res += fmt.Sprintf(" x%d (%s)", nbObvious, strings.Join(list, ", "))
-	}
-	return nbObvious, res
-}
-
-// ResolveNakedPairOptions based on https://sudoku.com/fr/regles-du-sudoku/paires-nues
-func (s Sudoku) ResolveNakedPairOptions(options Options) (int, string) {
-	// for each subsquare
-	actions := []string{}
-	for c := 0; c < s.size; c += 3 {
-		for r := 0; r < s.size; r += 3 {
-			// get options for current subsquare
-			subSquareFilter := FilterSubSquareFunc(r, c)
-			keep := func(opt Option) bool { return subSquareFilter(opt) && opt.Length() >= 2 }
-			localOptions := options.Filter(keep)
-			if len(localOptions) < 4 { // not enough options for naked pair technic
-				continue
-			}
-
-			// first and second localOptions must be a pair, otherwise no solution => skip to next subsquare
-			if localOptions[0].Length() != 2 || localOptions[1].Length() != 2 {
-				continue
-			}
-
-			// check if same pair, otherwise no solution => skip to next subsquare
-			pair := localOptions[0].option
-			if !localOptions[1].option.Contains(pair) {
-				continue
-			}
-
-			// we found our two pairs, remove them from remaining options
-			for _, option := range localOptions[2:] {
-				if option.option.Contains(pair) {
-					actions = append(actions, fmt.Sprintf("%s from %s", pair.String(), option.String()))
-					option.option.RemoveSet(pair)
-				}
-			}
-		}
-	}
-	nbNakedPairs := len(actions)
-	res := "Naked Pairs: "
-	if nbNakedPairs == 0 {
-		res += "    None"
-	} else {
-		res += fmt.Sprintf("   x%d (%s)", nbNakedPairs, strings.Join(actions, ", "))
-	}
-
-	return nbNakedPairs, res
-}
-
-// ResolveNakedTripletOptions based on https://sudoku.com/fr/regles-du-sudoku/triplets-nus
-func (s Sudoku) ResolveNakedTripletOptions(options Options) (int, string) {
-	// for each subsquare
-	actions := []string{}
-
-	controlTriplets := func(localOpts Options) {
-		//fmt.Printf("DEBUG TRIPLET controlTriplets: %d options: %s\n", len(localOpts), localOpts.String())
-		if len(localOpts) < 4 { // not enough options for naked triplets technic
-			return
-		}
-
-		// localOptions are sorted by ascending length. Three first localOptions must be a pair, otherwise no solution => skip to next subsquare
-		if localOpts[2].Length() != 2 {
-			return
-		}
-
-		// get possibles numbers given by three first localOptions
-		possibleNumbers := make(map[int]int)
-		for _, pair := range localOpts[:3] {
-			for _, v := range pair.GetValues() {
-				possibleNumbers[v]++
-			}
-		}
-		// check if there are 3 possibles numbers, each two times
-		if len(possibleNumbers) > 3 {
-			return
-		}
-		for _, nb := range possibleNumbers {
-			if nb != 2 {
-				return
-			}
-		}
-
-		// we found our three pairs, remove possibles numbers from remaining options
-		for _, option := range localOpts[3:] {
-			for _, pair := range localOpts[:3] {
-				if option.option.Contains(pair.option) {
-					actions = append(actions, fmt.Sprintf("%s from %s", pair.option.String(), option.String()))
-					option.option.RemoveSet(pair.option)
-				}
-			}
-		}
-	}
-
-	for c := 0; c < s.size; c += 3 {
-		for r := 0; r < s.size; r += 3 {
-			// get options for current subsquare
-			subSquareFilter := FilterSubSquareFunc(r, c)
-			keep := func(opt Option) bool { return subSquareFilter(opt) && opt.Length() >= 2 }
-			localOptions := options.Filter(keep)
-			controlTriplets(localOptions)
+		// value may have been removed from candidates by a previous placement
+		if s.place(&step, option.cell(), option.GetValues()[0]) {
+			step.Details = append(step.Details, option.String())
 		}
 	}
-
-	nbNakedTriplets := len(actions)
-	res := "Naked Triplets: "
-	if nbNakedTriplets == 0 {
-		res += "    None"
-	} else {
-		res += fmt.Sprintf(" x%d (%s)", nbNakedTriplets, strings.Join(actions, ", "))
-	}
-
-	return nbNakedTriplets, res
+	return step
 }
 
 // ResolveHiddenSingletonsOptions based on https://sudoku.com/fr/regles-du-sudoku/singletons-caches
-func (s Sudoku) ResolveHiddenSingletonsOptions(options Options) (int, string) {
-	// for each subsquare
-	actions := []string{}
-
-	controlHiddenSingleton := func(localOpts Options) {
-		//fmt.Printf("DEBUG HiddenSingleton control: %d options: %s\n", len(localOpts), localOpts.String())
-		if len(localOpts) < 1 { // not enough options for naked triplets technic
-			return
-		}
-
-		// get possibles numbers given by localOptions
-		possibleNumbers := make(map[int]int)
-		for _, pair := range localOpts {
-			for _, v := range pair.GetValues() {
-				possibleNumbers[v]++
-			}
-		}
-
-		// search and keep only singleton
-		for n, nb := range possibleNumbers {
-			if nb > 1 {
-				delete(possibleNumbers, n)
-			}
-		}
-
-		// process singleton
-		for _, option := range localOpts {
-			for n, _ := range possibleNumbers {
-				// if singleton is within this option, apply it
-				if _, found := option.option[n]; found {
-					option.option = ValueSet{n: struct{}{}}
-					actions = append(actions, fmt.Sprintf("%s", option.String()))
-					s.SetValue(n, option.row, option.col)
-					break
-				}
-			}
-		}
-	}
-
-	for c := 0; c < s.size; c += 3 {
-		for r := 0; r < s.size; r += 3 {
-			// get options for current subsquare
-			subSquareFilter := FilterSubSquareFunc(r, c)
-			keep := func(opt Option) bool { return subSquareFilter(opt) && opt.Length() >= 2 }
-			localOptions := options.Filter(keep)
-			controlHiddenSingleton(localOptions)
-		}
-	}
-
-	nbHiddenSingletons := len(actions)
-	res := "Hidden Singletons: "
-	if nbHiddenSingletons == 0 {
-		res += "    None"
-	} else {
-		res += fmt.Sprintf(" x%d (%s)", nbHiddenSingletons, strings.Join(actions, ", "))
-	}
-
-	return nbHiddenSingletons, res
-}
-
-// ResolveHiddenPairsOptions based on https://sudoku.com/fr/regles-du-sudoku/paires-cachees/
-func (s Sudoku) ResolveHiddenPairsOptions(options Options) (int, string) {
-	// for each subsquare
-	actions := []string{}
-
-	controlHiddenPairs := func(localOpts Options) {
-		//fmt.Printf("DEBUG HiddenPairs control: %d options: %s\n", len(localOpts), localOpts.String())
-		if len(localOpts) < 2 { // not enough options for hidden pairs technic
-			return
-		}
-
-		// get possible numbers given by localOptions
-		possibleNumbers := make(map[int]int)
-		for _, option := range localOpts {
-			for _, v := range option.GetValues() {
-				possibleNumbers[v]++
-			}
-		}
-
-		// search and keep only numbers with 2 possibles
-		for n, nb := range possibleNumbers {
-			if nb != 2 {
-				delete(possibleNumbers, n)
-			}
-		}
-		//fmt.Printf("DEBUG HiddenPairs control: possibles %v\n", possibleNumbers)
-		// check if there are at least 2 numbers with 2 possibles
-		if len(possibleNumbers) < 2 {
-			return
-		}
-
-		// search for available pairs of numbers
-		var targetOptions Options
-		var pair ValueSet
-	Search:
-		for i, _ := range possibleNumbers {
-			for j, _ := range possibleNumbers {
-				if i == j {
-					continue
-				}
-				pair = ValueSet{i: struct{}{}, j: struct{}{}}
-				// find options with this pair
-				targetOptions = Options{}
-				for _, option := range localOpts {
-					if option.option.Contains(pair) {
-						targetOptions = append(targetOptions, option)
-					}
-				}
-				if len(targetOptions) == 2 {
-					break Search
-				}
-			}
-		}
-		if len(targetOptions) != 2 {
-			return
-		}
-		//fmt.Printf("DEBUG HiddenPairs control: found pair %s in %s\n", pair.String(), targetOptions.String())
-
-		// remove all others possibles than pair from target options
-		actualOptions := []string{}
-		for _, option := range targetOptions {
-			if option.Length() < 3 { // do not process options with less than 3 possibles
-				continue
-			}
-			actualOptions = append(actualOptions, option.String())
-			option.option.RemoveButSet(pair)
-		}
-		if len(actualOptions) > 0 {
-			actions = append(actions, fmt.Sprintf("%s from %s", pair.String(), strings.Join(actualOptions, " / ")))
-		}
-	}
-
-	for c := 0; c < s.size; c += 3 {
-		for r := 0; r < s.size; r += 3 {
-			// get options for current subsquare
-			subSquareFilter := FilterSubSquareFunc(r, c)
-			keep := func(opt Option) bool { return subSquareFilter(opt) && opt.Length() >= 2 }
-			localOptions := options.Filter(keep)
-			controlHiddenPairs(localOptions)
-		}
-	}
-
-	nbHiddenPairs := len(actions)
-	res := "Hidden Pairs: "
-	if nbHiddenPairs == 0 {
-		res += "    None"
-	} else {
-		res += fmt.Sprintf(" x%d (%s)", nbHiddenPairs, strings.Join(actions, ", "))
-	}
-
-	return nbHiddenPairs, res
+func (s *Sudoku) ResolveHiddenSingletonsOptions() Step {
+	return s.resolveHiddenSingletons(HouseBox, HouseRow, HouseColumn)
 }
 
-// ResolveHiddenTripletsOptions based on https://sudoku.com/fr/regles-du-sudoku/triplets-caches/
-func (s Sudoku) ResolveHiddenTripletsOptions(options Options) (int, string) {
-	// for each subsquare
-	actions := []string{}
-
-	controlHiddenTriplets := func(localOpts Options) {
-		//fmt.Printf("DEBUG HiddenTriplets control: %d options: %s\n", len(localOpts), localOpts.String())
-		if len(localOpts) < 3 { // not enough options for hidden triplet technic
-			return
-		}
-
-		// get possible numbers given by localOptions
-		possibleNumbers := make(map[int]int)
-		for _, option := range localOpts {
-			for _, v := range option.GetValues() {
-				possibleNumbers[v]++
-			}
-		}
-
-		// search and keep only numbers with 3 possibles
-		for n, nb := range possibleNumbers {
-			if nb != 3 {
-				delete(possibleNumbers, n)
-			}
-		}
-		//fmt.Printf("DEBUG HiddenTriplets control: possibles %v\n", possibleNumbers)
-		// check if there are at least 3 numbers with 3 possibles
-		if len(possibleNumbers) < 3 {
-			return
-		}
-
-		// search for available triplet of numbers
-		var targetOptions Options
-		var triplet ValueSet
-	Search:
-		for i, _ := range possibleNumbers {
-			for j, _ := range possibleNumbers {
-				if i == j {
-					continue
-				}
-				for k, _ := range possibleNumbers {
-					if i == k || j == k {
-						continue
-					}
-					triplet = ValueSet{i: struct{}{}, j: struct{}{}, k: struct{}{}}
-					// find options with this pair
-					targetOptions = Options{}
-					for _, option := range localOpts {
-						if option.option.Contains(triplet) {
-							targetOptions = append(targetOptions, option)
-						}
-					}
-					if len(targetOptions) == 3 {
-						break Search
-					}
-				}
-			}
-		}
-		if len(targetOptions) != 3 {
-			return
+// resolveHiddenSingletons searches hidden singles in the houses of given kinds only: values having a single candidate
+// cell in a house. This cell may hold other candidates, or not (it is then a naked single too, such as the last empty
+// cell of a house)
+func (s *Sudoku) resolveHiddenSingletons(kinds ...HouseKind) Step {
+	step := Step{Technique: "Hidden Singletons"}
+	for _, house := range s.Houses() {
+		if !containsKind(kinds, house.Kind) {
+			continue
 		}
-		//fmt.Printf("DEBUG HiddenTriplets control: found triplet %s in %s\n", triplet.String(), targetOptions.String())
-
-		// remove all others possibles than triplet from target options
-		actualOptions := []string{}
-		for _, option := range targetOptions {
-			if option.Length() < 4 { // do not process options with less than 4 possibles
-				continue
+		for v := 1; v <= s.size; v++ {
+			// apply singleton, unless cell was set for another value of the house
+			if cells := s.valueCells(house, v); len(cells) == 1 && s.place(&step, cells[0], v) {
+				option := Option{row: cells[0].Row, col: cells[0].Col, option: NewValueSet(v)}
+				step.Details = append(step.Details, fmt.Sprintf("%s in %s", option.String(), house.String()))
 			}
-			actualOptions = append(actualOptions, option.String())
-			option.option.RemoveButSet(triplet)
-		}
-		if len(actualOptions) > 0 {
-			actions = append(actions, fmt.Sprintf("%s from %s", triplet.String(), strings.Join(actualOptions, " / ")))
 		}
 	}
-
-	for c := 0; c < s.size; c += 3 {
-		for r := 0; r < s.size; r += 3 {
-			// get options for current subsquare
-			subSquareFilter := FilterSubSquareFunc(r, c)
-			keep := func(opt Option) bool { return subSquareFilter(opt) && opt.Length() >= 2 }
-			localOptions := options.Filter(keep)
-			controlHiddenTriplets(localOptions)
-		}
-	}
-
-	nbHiddenTriplets := len(actions)
-	res := "Hidden Triplets: "
-	if nbHiddenTriplets == 0 {
-		res += "    None"
-	} else {
-		res += fmt.Sprintf(" x%d (%s)", nbHiddenTriplets, strings.Join(actions, ", "))
-	}
-
-	return nbHiddenTriplets, res
+	return step
 }
@@ -1,6 +1,9 @@
 package sudoku
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestSudoku_String(t *testing.T) {
 	s := New(9)
@@ -76,185 +79,253 @@ func TestSudoku_GetValid(t *testing.T) {
 	t.Logf(s.GetValid(7, 4).String())
 }
 
+// testPuzzles is a corpus of 9x9 puzzles in one-line format, used by solving tests
+var testPuzzles = []struct {
+	name, grid string
+}{
+	{"Sample", "..1.9..4...4....8.78...3.12..3..29.....5....667..3....2..3.4.6...5.....31.6..5.9."},
+	{"Very Easy", "9...3.4.....8....9742...6........7.....54.....5..7.81..65..9...........3.31..2..."},
+	{"Easy", "..5.3.......276....3..8..42.....746.8.13..7........13........7.482..3.1.17.5....."},
+	{"Difficult 1", ".3..5..4.2...3...5...........1.......4..6...2....4....3.......4................2."},
+	{"Difficult 2", "1...8...6.2.4...7...3...5....4..7.1.5...3...8.6.2..9....7...2...8...9.3.9...6...4"},
+	{"Difficult 3", "...1.2....6.....7...8...9..4.......3.5...7...2...8...1..9...8.5.7.....6....3.4..."},
+	{"Francoise' students", "8...1...94..872..3..3.6.4...4...6.7.3.6.5.2.1.8.....6.6.4.3.5.....6.1...2...9...6"},
+	{"Naked Pairs", "..2.85..4....3..6...421..3........52......31.9........8....6...25.4....8.....16.."},
+	{"Naked Triplets", "37.....9.9...7.......42...6..1.842...........8..6...5...6..2.1........39.5....4.."},
+	{"Hidden Pairs", "..9.32......7.....162.......1..2.56....9......5....1.7......4.3.26..9.....587...."},
+	{"Hidden Triplets", "..8..7....42..5..............3..68.1........69.........8.13.47.....9.....1......."},
+}
+
 func TestSudoku_Solve(t *testing.T) {
+	for _, tc := range testPuzzles {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Parse(tc.grid)
+			if err != nil {
+				t.Fatalf("could not parse puzzle: %v", err)
+			}
+			log := strings.Builder{}
+			Solver{Observer: NewTextObserver(&log)}.Solve(&s)
+			t.Log(log.String())
+			checkSolved(t, s)
+		})
+	}
+}
+
+func TestSolver_Observer(t *testing.T) {
+	s, err := Parse(testPuzzles[2].grid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []Event{}
+	_, completed := Solver{Observer: ObserverFunc(func(e Event) { events = append(events, e) })}.Solve(&s)
+	if !completed {
+		t.Fatalf("sudoku should be completed:\n%s", s.String())
+	}
+	if len(events) < 3 || events[0].Kind != EventStart || events[len(events)-1].Kind != EventEnd {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	for _, e := range events {
+		if e.Kind != EventStep {
+			continue
+		}
+		if e.Step.Technique == "" || !e.Step.Found() || len(e.Step.Details) == 0 {
+			t.Errorf("unexpected step event %+v", e)
+		}
+		if e.Depth > 0 {
+			continue
+		}
+		for _, p := range e.Step.Placements {
+			if s.GetValue(p.Row, p.Col) != p.Value {
+				t.Errorf("placement %s does not match solution", p.String())
+			}
+		}
+	}
+
+	log := strings.Builder{}
+	textObserver := NewTextObserver(&log)
+	for _, e := range events {
+		textObserver.Observe(e)
+	}
+	if !strings.Contains(log.String(), "Obvious Options: x") || !strings.HasSuffix(log.String(), s.String()) {
+		t.Errorf("unexpected text log:\n%s", log.String())
+	}
+}
+
+func TestNewWithBox(t *testing.T) {
+	for _, tc := range []struct {
+		size, width, height int
+	}{
+		{4, 2, 2},
+		{6, 3, 2},
+		{9, 3, 3},
+		{12, 4, 3},
+		{16, 4, 4},
+		{25, 5, 5},
+	} {
+		s := New(tc.size)
+		w, h := s.BoxSize()
+		if w != tc.width || h != tc.height || s.Size() != tc.size {
+			t.Errorf("New(%d): got size %d and %dx%d boxes, expected %dx%d boxes", tc.size, s.Size(), w, h, tc.width, tc.height)
+		}
+	}
+}
+
+func TestValidateBox(t *testing.T) {
+	for _, tc := range []struct {
+		width, height int
+		valid         bool
+	}{
+		{3, 2, true},
+		{2, 3, true},
+		{5, 5, true},
+		{9, 1, false},
+		{1, 4, false},
+		{0, 3, false},
+		{-2, 2, false},
+		{6, 6, false},
+		{13, 2, false},
+	} {
+		if err := ValidateBox(tc.width, tc.height); (err == nil) != tc.valid {
+			t.Errorf("ValidateBox(%d, %d): unexpected error %v", tc.width, tc.height, err)
+		}
+	}
+}
+
+func TestNewWithBox_Panic(t *testing.T) {
+	for _, dims := range [][2]int{{0, 3}, {-2, 2}, {6, 6}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewWithBox(%d, %d) should panic", dims[0], dims[1])
+				}
+			}()
+			NewWithBox(dims[0], dims[1])
+		}()
+	}
+}
+
+func TestNew_RoundTrip(t *testing.T) {
+	// sizes accepted by New are the ones read back by Parse
+	for size := 1; size <= maxSize+1; size++ {
+		width, height := boxDimensions(size)
+		if err := ValidateBox(width, height); err != nil {
+			if _, perr := Parse(strings.Repeat(".", size*size)); perr == nil {
+				t.Errorf("size %d: Parse should reject the grid New rejects", size)
+			}
+			continue
+		}
+		s := New(size)
+		s.SetValue(size, 0, 0)
+		res, err := Parse(s.LineString())
+		if err != nil {
+			t.Errorf("size %d: %v", size, err)
+			continue
+		}
+		if w, h := res.BoxSize(); res.LineString() != s.LineString() || w != width || h != height {
+			t.Errorf("size %d: read back %s with %dx%d boxes", size, res.LineString(), w, h)
+		}
+	}
+}
+
+func TestSudoku_String_Header(t *testing.T) {
 	s := New(9)
-	//s.values = []int{
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 0,
-	//}
-
-	//s.values = []int{
-	//	0, 0, 1,  0, 9, 0,  0, 4, 0,
-	//	0, 0, 4,  0, 0, 0,  0, 8, 0,
-	//	7, 8, 0,  0, 0, 3,  0, 1, 2,
-	//
-	//	0, 0, 3,  0, 0, 2,  9, 0, 0,
-	//	0, 0, 0,  5, 0, 0,  0, 0, 6,
-	//	6, 7, 0,  0, 3, 0,  0, 0, 0,
-	//
-	//	2, 0, 0,  3, 0, 4,  0, 6, 0,
-	//	0, 0, 5,  0, 0, 0,  0, 0, 3,
-	//	1, 0, 6,  0, 0, 5,  0, 9, 0,
-	//}
-
-	// Very Easy
-	//s.values = []int{
-	//	9, 0, 0,  0, 3, 0,  4, 0, 0,
-	//	0, 0, 0,  8, 0, 0,  0, 0, 9,
-	//	7, 4, 2,  0, 0, 0,  6, 0, 0,
-	//
-	//	0, 0, 0,  0, 0, 0,  7, 0, 0,
-	//	0, 0, 0,  5, 4, 0,  0, 0, 0,
-	//	0, 5, 0,  0, 7, 0,  8, 1, 0,
-	//
-	//	0, 6, 5,  0, 0, 9,  0, 0, 0,
-	//	0, 0, 0,  0, 0, 0,  0, 0, 3,
-	//	0, 3, 1,  0, 0, 2,  0, 0, 0,
-	//}
-
-	// Easy
-	//s.values = []int{
-	//	0, 0, 5, 0, 3, 0, 0, 0, 0,
-	//	0, 0, 0, 2, 7, 6, 0, 0, 0,
-	//	0, 3, 0, 0, 8, 0, 0, 4, 2,
-	//
-	//	0, 0, 0, 0, 0, 7, 4, 6, 0,
-	//	8, 0, 1, 3, 0, 0, 7, 0, 0,
-	//	0, 0, 0, 0, 0, 0, 1, 3, 0,
-	//
-	//	0, 0, 0, 0, 0, 0, 0, 7, 0,
-	//	4, 8, 2, 0, 0, 3, 0, 1, 0,
-	//	1, 7, 0, 5, 0, 0, 0, 0, 0,
-	//}
-
-	// Difficult
-	//s.values = []int{
-	//	0, 3, 0, 0, 5, 0, 0, 4, 0,
-	//	2, 0, 0, 0, 3, 0, 0, 0, 5,
-	//	0, 0, 0, 0, 0, 0, 0, 0, 0,
-	//
-	//	0, 0, 1, 0, 0, 0, 0, 0, 0,
-	//	0, 4, 0, 0, 6, 0, 0, 0, 2,
-	//	0, 0, 0, 0, 4, 0, 0, 0, 0,
-	//
-	//	3, 0, 0, 0, 0, 0, 0, 0, 4,
-	//	0, 0, 0, 0, 0, 0, 0, 0, 0,
-	//	0, 0, 0, 0, 0, 0, 0, 2, 0,
-	//}
-
-	// Difficult
-	//s.values = []int{
-	//	1, 0, 0, 0, 8, 0, 0, 0, 6,
-	//	0, 2, 0, 4, 0, 0, 0, 7, 0,
-	//	0, 0, 3, 0, 0, 0, 5, 0, 0,
-	//
-	//	0, 0, 4, 0, 0, 7, 0, 1, 0,
-	//	5, 0, 0, 0, 3, 0, 0, 0, 8,
-	//	0, 6, 0, 2, 0, 0, 9, 0, 0,
-	//
-	//	0, 0, 7, 0, 0, 0, 2, 0, 0,
-	//	0, 8, 0, 0, 0, 9, 0, 3, 0,
-	//	9, 0, 0, 0, 6, 0, 0, 0, 4,
-	//}
-
-	// Difficult
-	//s.values = []int{
-	//	0, 0, 0, 1, 0, 2, 0, 0, 0,
-	//	0, 6, 0, 0, 0, 0, 0, 7, 0,
-	//	0, 0, 8, 0, 0, 0, 9, 0, 0,
-	//
-	//	4, 0, 0, 0, 0, 0, 0, 0, 3,
-	//	0, 5, 0, 0, 0, 7, 0, 0, 0,
-	//	2, 0, 0, 0, 8, 0, 0, 0, 1,
-	//
-	//	0, 0, 9, 0, 0, 0, 8, 0, 5,
-	//	0, 7, 0, 0, 0, 0, 0, 6, 0,
-	//	0, 0, 0, 3, 0, 4, 0, 0, 0,
-	//}
-
-	// test Francoise' students
-	//s.values = []int{
-	//	8, 0, 0, 0, 1, 0, 0, 0, 9,
-	//	4, 0, 0, 8, 7, 2, 0, 0, 3,
-	//	0, 0, 3, 0, 6, 0, 4, 0, 0,
-	//
-	//	0, 4, 0, 0, 0, 6, 0, 7, 0,
-	//	3, 0, 6, 0, 5, 0, 2, 0, 1,
-	//	0, 8, 0, 0, 0, 0, 0, 6, 0,
-	//
-	//	6, 0, 4, 0, 3, 0, 5, 0, 0,
-	//	0, 0, 0, 6, 0, 1, 0, 0, 0,
-	//	2, 0, 0, 0, 9, 0, 0, 0, 6,
-	//}
-
-	// test Naked Pairs
-	//s.values = []int{
-	//	0, 0, 2, 0, 8, 5, 0, 0, 4,
-	//	0, 0, 0, 0, 3, 0, 0, 6, 0,
-	//	0, 0, 4, 2, 1, 0, 0, 3, 0,
-	//
-	//	0, 0, 0, 0, 0, 0, 0, 5, 2,
-	//	0, 0, 0, 0, 0, 0, 3, 1, 0,
-	//	9, 0, 0, 0, 0, 0, 0, 0, 0,
-	//
-	//	8, 0, 0, 0, 0, 6, 0, 0, 0,
-	//	2, 5, 0, 4, 0, 0, 0, 0, 8,
-	//	0, 0, 0, 0, 0, 1, 6, 0, 0,
-	//}
-
-	// test Naked Triplets
-	//s.values = []int{
-	//	3, 7, 0, 0, 0, 0, 0, 9, 0,
-	//	9, 0, 0, 0, 7, 0, 0, 0, 0,
-	//	0, 0, 0, 4, 2, 0, 0, 0, 6,
-	//
-	//	0, 0, 1, 0, 8, 4, 2, 0, 0,
-	//	0, 0, 0, 0, 0, 0, 0, 0, 0,
-	//	8, 0, 0, 6, 0, 0, 0, 5, 0,
-	//
-	//	0, 0, 6, 0, 0, 2, 0, 1, 0,
-	//	0, 0, 0, 0, 0, 0, 0, 3, 9,
-	//	0, 5, 0, 0, 0, 0, 4, 0, 0,
-	//}
-
-	// test Hidden Pairs
-	//s.values = []int{
-	//	0, 0, 9, 0, 3, 2, 0, 0, 0,
-	//	0, 0, 0, 7, 0, 0, 0, 0, 0,
-	//	1, 6, 2, 0, 0, 0, 0, 0, 0,
-	//
-	//	0, 1, 0, 0, 2, 0, 5, 6, 0,
-	//	0, 0, 0, 9, 0, 0, 0, 0, 0,
-	//	0, 5, 0, 0, 0, 0, 1, 0, 7,
-	//
-	//	0, 0, 0, 0, 0, 0, 4, 0, 3,
-	//	0, 2, 6, 0, 0, 9, 0, 0, 0,
-	//	0, 0, 5, 8, 7, 0, 0, 0, 0,
-	//}
-
-	// test Hidden Triplets
-	s.values = []int{
-		0, 0, 8, 0, 0, 7, 0, 0, 0,
-		0, 4, 2, 0, 0, 5, 0, 0, 0,
-		0, 0, 0, 0, 0, 0, 0, 0, 0,
+	header := "       A  B  C  .  D  E  F  .  G  H  I\n"
+	separator := "   -  ----------+-----------+----------\n"
+	res := s.String()
+	if !strings.HasPrefix(res, header) {
+		t.Errorf("unexpected header in\n%s", res)
+	}
+	if !strings.Contains(res, separator) {
+		t.Errorf("unexpected separator in\n%s", res)
+	}
 
-		0, 0, 3, 0, 0, 6, 8, 0, 1,
-		0, 0, 0, 0, 0, 0, 0, 0, 6,
-		9, 0, 0, 0, 0, 0, 0, 0, 0,
+	s = NewWithBox(4, 4)
+	s.SetValue(16, 0, 15)
+	res = s.String()
+	if !strings.Contains(res, " G \n") {
+		t.Errorf("value 16 should be displayed as G in\n%s", res)
+	}
+	t.Log(res)
+}
 
-		0, 8, 0, 1, 3, 0, 4, 7, 0,
-		0, 0, 0, 0, 9, 0, 0, 0, 0,
-		0, 1, 0, 0, 0, 0, 0, 0, 0,
+// checkSolved fails the test if given sudoku is not completed with valid values
+func checkSolved(t *testing.T, s Sudoku) {
+	t.Helper()
+	if !s.Completed() {
+		t.Fatalf("sudoku is not completed:\n%s", s.String())
+	}
+	for r := 0; r < s.size; r++ {
+		for c := 0; c < s.size; c++ {
+			if !s.IsValid(s.getValue(r, c), r, c) {
+				t.Fatalf("invalid value at %s:\n%s", Option{row: r, col: c}.posString(), s.String())
+			}
+		}
 	}
+}
 
-	s.Solve(0)
+func TestSudoku_Solve_Geometries(t *testing.T) {
+	// 4x4 grid with 2x2 boxes
+	s4 := New(4)
+	s4.values = []int{
+		1, 0, 0, 0,
+		0, 0, 3, 0,
+
+		0, 4, 0, 0,
+		0, 0, 0, 2,
+	}
+	s4.Solve(0)
+	checkSolved(t, s4)
+
+	// 6x6 grid with 3x2 boxes
+	s6 := NewWithBox(3, 2)
+	s6.values = []int{
+		1, 0, 3, 0, 5, 0,
+		0, 5, 0, 1, 0, 3,
+
+		2, 0, 1, 0, 6, 0,
+		0, 6, 0, 2, 0, 1,
+
+		3, 0, 2, 0, 4, 0,
+		0, 4, 0, 3, 0, 2,
+	}
+	s6.Solve(0)
+	checkSolved(t, s6)
+
+	// 16x16 grid with 4x4 boxes, built from a valid pattern with some cells removed
+	s16 := New(16)
+	for r := 0; r < 16; r++ {
+		for c := 0; c < 16; c++ {
+			if (r*7+c*3)%5 == 0 {
+				continue
+			}
+			s16.SetValue((4*(r%4)+r/4+c)%16+1, r, c)
+		}
+	}
+	s16.Solve(0)
+	checkSolved(t, s16)
+}
+
+func BenchmarkSolver_Corpus(b *testing.B) {
+	puzzles := []Sudoku{}
+	for _, tc := range testPuzzles {
+		s, err := Parse(tc.grid)
+		if err != nil {
+			b.Fatal(err)
+		}
+		puzzles = append(puzzles, s)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, puzzle := range puzzles {
+			s := puzzle.Clone()
+			Solver{}.Solve(&s)
+		}
+	}
+}
+
+func BenchmarkSudoku_GetAllOptions(b *testing.B) {
+	s, _ := Parse(testPuzzles[5].grid)
+	for i := 0; i < b.N; i++ {
+		s.GetAllOptions()
+	}
 }
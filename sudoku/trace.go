@@ -0,0 +1,233 @@
+package sudoku
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Cell identifies a grid position (0-based row and column)
+type Cell struct {
+	Row, Col int
+}
+
+// String returns the cell position with column letter and row number (A1 for top left cell)
+func (c Cell) String() string {
+	return Option{row: c.Row, col: c.Col}.posString()
+}
+
+// Placement records a value set in a cell
+type Placement struct {
+	Cell
+	Value int
+}
+
+func (p Placement) String() string {
+	return fmt.Sprintf("%s=%s", p.Cell.String(), valueString(p.Value))
+}
+
+// Elimination records candidate values removed from a cell
+type Elimination struct {
+	Cell
+	Values []int
+}
+
+func (e Elimination) String() string {
+	return fmt.Sprintf("%s-%s", e.Cell.String(), NewValueSet(e.Values...).String())
+}
+
+// Candidate identifies a candidate value of a cell
+type Candidate struct {
+	Cell
+	Value int
+}
+
+// String returns the candidate in Eureka style, value first then cell ("(5)A3")
+func (c Candidate) String() string {
+	return fmt.Sprintf("(%s)%s", valueString(c.Value), c.Cell.String())
+}
+
+// Coloring records a graph of candidates connected by strong links (of which at least one candidate is true), colored
+// with two alternating colors: all candidates of one of the colors are true, all candidates of the other are false
+type Coloring struct {
+	Colors [2][]Candidate // candidates of each color
+	Links  [][2]Candidate // strong links connecting all candidates (a spanning tree of the graph)
+	False  int            // index of the color proven false, -1 if none (eliminations then come from both colors)
+}
+
+// Chain records an alternating inference chain: Nodes are alternately linked by strong links (at least one of the
+// two candidates is true) and weak links (at most one of them is true), starting with a strong link
+type Chain struct {
+	Nodes        []Candidate
+	Loop         bool // continuous nice loop: last node is weakly linked to the first one
+	Placements   []Placement
+	Eliminations []Elimination
+}
+
+// String returns the chain in Eureka notation: "=" for strong links, "-" for weak links, strong links within a cell
+// being grouped ("(5)A3=(5)G3-(5=2)G8-(2)A8")
+func (c Chain) String() string {
+	sb := strings.Builder{}
+	for i := 0; i < len(c.Nodes); i++ {
+		node := c.Nodes[i]
+		if i > 0 {
+			sb.WriteString(linkString(i - 1))
+		}
+		if i%2 == 0 && i+1 < len(c.Nodes) && c.Nodes[i+1].Cell == node.Cell {
+			sb.WriteString(fmt.Sprintf("(%s=%s)%s", valueString(node.Value), valueString(c.Nodes[i+1].Value), node.Cell.String()))
+			i++
+			continue
+		}
+		sb.WriteString(node.String())
+	}
+	if c.Loop {
+		sb.WriteString(linkString(len(c.Nodes)-1) + c.Nodes[0].String())
+	}
+	return sb.String()
+}
+
+// linkString returns the Eureka symbol of the i-th link of a chain (links alternate, starting with a strong link)
+func linkString(i int) string {
+	if i%2 == 0 {
+		return "="
+	}
+	return "-"
+}
+
+// Wing records a wing pattern: pincer cells linked through a pivot, such that one of the pincers holds Value.
+// Value can then be removed from the cells seeing both pincers (and the pivot for XYZ-Wings)
+type Wing struct {
+	Pivot        []Cell  // pivot cell (XY-Wing and XYZ-Wing), or the two cells of the strong link (W-Wing)
+	Pincers      [2]Cell // pincer cells
+	Value        int     // value eliminated
+	Eliminations []Elimination
+}
+
+// ALS records an almost locked set: N unsolved cells of a house holding N+1 candidates altogether. Should one of its
+// values be removed, the others would make a locked set (each of them set in one of the cells)
+type ALS struct {
+	House   House
+	Options Options  // cells of the set, with their candidates
+	Values  ValueSet // candidates of all cells
+}
+
+// String returns the set cells with their candidates, and the house holding them ("A1[1, 2], A2[1, 2, 3] in column A")
+func (a ALS) String() string {
+	return fmt.Sprintf("%s in %s", a.Options.String(), a.House.String())
+}
+
+// Step records the deductions made by one solving technique
+type Step struct {
+	Technique    string
+	Placements   []Placement
+	Eliminations []Elimination
+	Details      []string   // human readable description of each deduction
+	Wings        []Wing     // wing patterns found (wing techniques only)
+	Colorings    []Coloring // coloring graphs used (coloring techniques only)
+	Chains       []Chain    // inference chains used (chain techniques only)
+	ALS          []ALS      // almost locked sets used (ALS techniques only)
+	Region       []House    // houses holding the affected cells (set by NextStep only)
+
+	// index in Details of the deduction of each placement and elimination, count of Details when each ALS was added
+	placementDetails, eliminationDetails, alsDetails []int
+}
+
+// addALS records the almost locked sets used by the deductions just made
+func (st *Step) addALS(sets ...ALS) {
+	for _, set := range sets {
+		st.ALS = append(st.ALS, set)
+		st.alsDetails = append(st.alsDetails, len(st.Details))
+	}
+}
+
+// Found returns true if receiver holds at least one placement or elimination
+func (st Step) Found() bool {
+	return len(st.Placements)+len(st.Eliminations) > 0
+}
+
+// Cells returns the cells affected by receiver placements and eliminations
+func (st Step) Cells() []Cell {
+	res := []Cell{}
+	known := make(map[Cell]bool)
+	add := func(c Cell) {
+		if !known[c] {
+			known[c] = true
+			res = append(res, c)
+		}
+	}
+	for _, p := range st.Placements {
+		add(p.Cell)
+	}
+	for _, e := range st.Eliminations {
+		add(e.Cell)
+	}
+	return res
+}
+
+func (st Step) String() string {
+	if len(st.Details) == 0 {
+		return fmt.Sprintf("%s: None", st.Technique)
+	}
+	return fmt.Sprintf("%s: x%d (%s)", st.Technique, len(st.Details), strings.Join(st.Details, ", "))
+}
+
+// EventKind gives the kind of a solving Event
+type EventKind int
+
+const (
+	// EventStart is sent when solving (or a recursive solving attempt) starts; Grid holds the grid to solve
+	EventStart EventKind = iota
+	// EventStep is sent when a technique made deductions; Step holds them
+	EventStep
+	// EventGuess is sent when a value is tried for a cell by the recursive strategy; Guess and Candidates hold them
+	EventGuess
+	// EventEnd is sent when no more option is available; Grid and Completed hold the final state
+	EventEnd
+)
+
+// Event is a structured solving trace record delivered to an Observer
+type Event struct {
+	Kind       EventKind
+	Depth      int       // recursion depth of the solving attempt
+	MaxDepth   int       // maximum recursion depth reached so far (EventGuess only)
+	Step       Step      // EventStep only
+	Guess      Placement // EventGuess only
+	Candidates []int     // candidate values of guessed cell (EventGuess only)
+	Grid       Sudoku    // EventStart and EventEnd only
+	Completed  bool      // EventEnd only
+}
+
+// Observer receives solving events
+type Observer interface {
+	Observe(e Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface
+type ObserverFunc func(e Event)
+
+func (f ObserverFunc) Observe(e Event) {
+	f(e)
+}
+
+// textObserver writes events as human readable text
+type textObserver struct {
+	w io.Writer
+}
+
+// NewTextObserver returns an Observer writing a human readable solving log to w
+func NewTextObserver(w io.Writer) Observer {
+	return textObserver{w: w}
+}
+
+func (to textObserver) Observe(e Event) {
+	switch e.Kind {
+	case EventStart:
+		fmt.Fprint(to.w, e.Grid.String())
+	case EventStep:
+		fmt.Fprintln(to.w, e.Step.String())
+	case EventGuess:
+		fmt.Fprintf(to.w, "(depth = %d/%d) Set possible %d of %s%s\n", e.Depth, e.MaxDepth, e.Guess.Value, e.Guess.Cell.String(), NewValueSet(e.Candidates...).String())
+	case EventEnd:
+		fmt.Fprintf(to.w, "No other options, sudoku completed=%v\n%s", e.Completed, e.Grid.String())
+	}
+}
@@ -0,0 +1,361 @@
+package sudoku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Uniqueness based techniques: a deadly pattern is a set of cells whose values could be swapped, giving another
+// solution. Puzzles having a unique solution contain none, so candidates completing one can be removed.
+//
+// The deadly pattern used here is a rectangle of four cells spread over two rows, two columns and two boxes, holding
+// the same two values (each diagonal holding one of them)
+
+// forEachRectangle calls fn for each rectangle of cells spread over exactly 2 rows, 2 columns and 2 boxes. Cells are
+// given row by row: top left, top right, bottom left and bottom right (cells[i] and cells[3-i] are diagonal)
+func (s *Sudoku) forEachRectangle(fn func(cells [4]Cell)) {
+	for r1 := 0; r1 < s.size; r1++ {
+		for r2 := r1 + 1; r2 < s.size; r2++ {
+			for c1 := 0; c1 < s.size; c1++ {
+				for c2 := c1 + 1; c2 < s.size; c2++ {
+					cells := [4]Cell{{r1, c1}, {r1, c2}, {r2, c1}, {r2, c2}}
+					boxes := make(map[House]bool)
+					for _, cell := range cells {
+						boxes[s.geo.house(HouseBox, cell.Row, cell.Col)] = true
+					}
+					if len(boxes) == 2 {
+						fn(cells)
+					}
+				}
+			}
+		}
+	}
+}
+
+// deadlyPattern describes the deadly pattern made of values in rectangle cells
+func deadlyPattern(cells [4]Cell, values ValueSet) string {
+	names := make([]string, len(cells))
+	for i, cell := range cells {
+		names[i] = cell.String()
+	}
+	return fmt.Sprintf("deadly pattern %s in %s", values.String(), strings.Join(names, ", "))
+}
+
+// commonHouses returns the houses containing both given cells
+func (s *Sudoku) commonHouses(a, b Cell) []House {
+	res := []House{}
+	for _, kind := range []HouseKind{HouseBox, HouseRow, HouseColumn} {
+		if h := s.geo.house(kind, a.Row, a.Col); h == s.geo.house(kind, b.Row, b.Col) {
+			res = append(res, h)
+		}
+	}
+	return res
+}
+
+// removeCandidates removes values from the candidates of given cell, describing the elimination with reason
+func (s *Sudoku) removeCandidates(step *Step, cell Cell, values ValueSet, reason string) {
+	grid := s.Candidates()
+	removed := grid.Get(cell.Row, cell.Col).Intersection(values)
+	if removed == 0 {
+		return
+	}
+	option := grid.option(cell)
+	s.eliminate(step, cell, removed)
+	step.Details = append(step.Details, fmt.Sprintf("%s from %s (%s)", removed.String(), option.String(), reason))
+}
+
+// ResolveUniqueRectangleOptions based on https://www.sudokuwiki.org/Unique_Rectangles (types 1 to 6)
+//
+// Only valid for puzzles having a unique solution
+func (s *Sudoku) ResolveUniqueRectangleOptions() Step {
+	step := Step{Technique: "Unique Rectangle"}
+	grid := s.Candidates()
+	s.forEachRectangle(func(cells [4]Cell) {
+		common := fullValueSet(s.size)
+		for _, cell := range cells {
+			common = common.Intersection(grid.Get(cell.Row, cell.Col))
+		}
+		values := common.GetValues()
+		for i, a := range values {
+			for _, b := range values[i+1:] {
+				s.uniqueRectangle(&step, cells, NewValueSet(a, b))
+			}
+		}
+	})
+	return step
+}
+
+// uniqueRectangle searches unique rectangle deductions on rectangle cells, all having pair values as candidates
+func (s *Sudoku) uniqueRectangle(step *Step, cells [4]Cell, pair ValueSet) {
+	grid := s.Candidates()
+	var extras [4]ValueSet
+	bivalues, others := []int{}, []int{}
+	for i, cell := range cells {
+		extras[i] = grid.Get(cell.Row, cell.Col).Difference(pair)
+		if extras[i] == 0 {
+			bivalues = append(bivalues, i)
+		} else {
+			others = append(others, i)
+		}
+	}
+	reason := func(urType int) string {
+		return fmt.Sprintf("unique rectangle type %d, avoids %s", urType, deadlyPattern(cells, pair))
+	}
+	// deductions only hold while all cells can still make the deadly pattern (a previous deduction may have
+	// removed pair values)
+	intact := func() bool {
+		return s.rectangleHolds(cells, pair)
+	}
+	// one extra value c shared by all non bivalue cells: one of them is c
+	sharedExtra := func() (ValueSet, []Cell) {
+		extra, seen := extras[others[0]], []Cell{}
+		for _, i := range others {
+			if extras[i] != extra {
+				return 0, nil
+			}
+			seen = append(seen, cells[i])
+		}
+		if extra.Length() != 1 {
+			return 0, nil
+		}
+		return extra, seen
+	}
+
+	switch len(bivalues) {
+	case 3:
+		// type 1: the last cell can't hold pair values
+		s.removeCandidates(step, cells[others[0]], pair, reason(1))
+	case 2:
+		r1, r2 := cells[others[0]], cells[others[1]]
+		diagonal := bivalues[0]+bivalues[1] == 3
+		if extra, seen := sharedExtra(); extra != 0 {
+			// type 2 (non bivalue cells in the same row or column), or type 5 (diagonal non bivalue cells)
+			urType := 2
+			if diagonal {
+				urType = 5
+			}
+			for _, cell := range s.seenByAll(seen) {
+				if intact() {
+					s.removeCandidates(step, cell, extra, reason(urType))
+				}
+			}
+		}
+		if diagonal {
+			// type 6: a value of pair only possible in rectangle cells in both rows (or both columns) is in the
+			// bivalue cells, so it can be removed from the other ones
+			pair.Each(func(v int) {
+				for _, kind := range []HouseKind{HouseRow, HouseColumn} {
+					h1, h2 := s.geo.house(kind, cells[0].Row, cells[0].Col), s.geo.house(kind, cells[3].Row, cells[3].Col)
+					if intact() && len(s.valueCells(h1, v)) == 2 && len(s.valueCells(h2, v)) == 2 {
+						s.removeCandidates(step, r1, NewValueSet(v), reason(6))
+						s.removeCandidates(step, r2, NewValueSet(v), reason(6))
+					}
+				}
+			})
+			return
+		}
+		for _, house := range s.commonHouses(r1, r2) {
+			// type 4: a value of pair only possible in the non bivalue cells of a house is in one of them, so the
+			// other value of pair can be removed from both
+			pair.Each(func(v int) {
+				if intact() && len(s.valueCells(house, v)) == 2 {
+					s.removeCandidates(step, r1, pair.Difference(NewValueSet(v)), reason(4))
+					s.removeCandidates(step, r2, pair.Difference(NewValueSet(v)), reason(4))
+				}
+			})
+			// type 3: extra values of the non bivalue cells act as a single cell, making a naked subset with other
+			// cells of the house
+			if intact() {
+				s.uniqueRectangleSubset(step, house, r1, r2, extras[others[0]].Union(extras[others[1]]), reason(3))
+			}
+		}
+	case 1:
+		// type 5 with three non bivalue cells
+		if extra, seen := sharedExtra(); extra != 0 {
+			for _, cell := range s.seenByAll(seen) {
+				if intact() {
+					s.removeCandidates(step, cell, extra, reason(5))
+				}
+			}
+		}
+	}
+}
+
+// rectangleHolds returns true if all rectangle cells have pair values as candidates
+func (s *Sudoku) rectangleHolds(cells [4]Cell, pair ValueSet) bool {
+	for _, cell := range cells {
+		if !s.Candidates().Get(cell.Row, cell.Col).Contains(pair) {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueRectangleSubset searches a naked subset in house made of the virtual cell holding extra values of cells r1 and
+// r2, and of other cells of the house
+func (s *Sudoku) uniqueRectangleSubset(step *Step, house House, r1, r2 Cell, extra ValueSet, reason string) {
+	grid := s.Candidates()
+	cells := []Cell{}
+	for _, cell := range s.HouseCells(house) {
+		if cell != r1 && cell != r2 && grid.Get(cell.Row, cell.Col) != 0 {
+			cells = append(cells, cell)
+		}
+	}
+	for size := 1; size < len(cells) && size < 4; size++ {
+		forEachCombination(len(cells), size, func(chosen []int) {
+			subset := extra
+			members := map[Cell]bool{r1: true, r2: true}
+			names := []string{}
+			for _, i := range chosen {
+				subset = subset.Union(grid.Get(cells[i].Row, cells[i].Col))
+				members[cells[i]] = true
+				names = append(names, grid.option(cells[i]).String())
+			}
+			if subset.Length() != size+1 {
+				return
+			}
+			for _, cell := range cells {
+				if !members[cell] {
+					s.removeCandidates(step, cell, subset, fmt.Sprintf("%s, naked %s with %s in %s", reason, subset.String(), strings.Join(names, " / "), house.String()))
+				}
+			}
+		})
+	}
+}
+
+// ResolveHiddenUniqueRectangleOptions based on https://www.sudokuwiki.org/Hidden_Unique_Rectangles
+//
+// When a rectangle corner holds only the pair values [a, b], and a is only possible in rectangle cells in both the
+// row and the column of the opposite corner, this opposite corner can't be b (the rectangle would be a deadly
+// pattern). Only valid for puzzles having a unique solution
+func (s *Sudoku) ResolveHiddenUniqueRectangleOptions() Step {
+	step := Step{Technique: "Hidden Unique Rectangle"}
+	grid := s.Candidates()
+	s.forEachRectangle(func(cells [4]Cell) {
+		for i, corner := range cells {
+			pair := grid.Get(corner.Row, corner.Col)
+			if pair.Length() != 2 {
+				continue
+			}
+			opposite := cells[3-i]
+			pair.Each(func(v int) {
+				row, col := s.geo.house(HouseRow, opposite.Row, opposite.Col), s.geo.house(HouseColumn, opposite.Row, opposite.Col)
+				if s.rectangleHolds(cells, pair) && len(s.valueCells(row, v)) == 2 && len(s.valueCells(col, v)) == 2 {
+					reason := fmt.Sprintf("hidden unique rectangle, %s only in rectangle cells of %s and %s, avoids %s", NewValueSet(v).String(), row.String(), col.String(), deadlyPattern(cells, pair))
+					s.removeCandidates(&step, opposite, pair.Difference(NewValueSet(v)), reason)
+				}
+			})
+		}
+	})
+	return step
+}
+
+// ResolveAvoidableRectangleOptions based on https://www.sudokuwiki.org/Avoidable_Rectangles
+//
+// Rectangles whose cells were solved (not puzzle givens) can't make a deadly pattern either: when solved cells of a
+// rectangle are half a deadly pattern, the unsolved cells can't complete it. Only valid for puzzles having a unique
+// solution
+func (s *Sudoku) ResolveAvoidableRectangleOptions() Step {
+	step := Step{Technique: "Avoidable Rectangle"}
+	grid := s.Candidates()
+	s.forEachRectangle(func(cells [4]Cell) {
+		solved, unsolved := []int{}, []int{}
+		for i, cell := range cells {
+			switch {
+			case grid.isGiven(cell):
+				return
+			case s.getValue(cell.Row, cell.Col) != valueUndef:
+				solved = append(solved, i)
+			default:
+				unsolved = append(unsolved, i)
+			}
+		}
+		// in a deadly pattern, each cell holds the value of its diagonal cell, and the two values differ
+		required := func(i int) int {
+			return s.getValue(cells[3-i].Row, cells[3-i].Col)
+		}
+		switch len(unsolved) {
+		case 1:
+			// type 1: three solved cells, the last one can't hold the value completing the pattern
+			u := unsolved[0]
+			a, b := required(u), 0
+			for _, i := range solved {
+				if i == 3-u {
+					continue
+				}
+				if v := s.getValue(cells[i].Row, cells[i].Col); b == 0 {
+					b = v
+				} else if v != b {
+					return
+				}
+			}
+			if a == b {
+				return
+			}
+			s.removeCandidates(&step, cells[u], NewValueSet(a), fmt.Sprintf("avoidable rectangle type 1, avoids %s", deadlyPattern(cells, NewValueSet(a, b))))
+		case 2:
+			// type 2: two solved cells in the same row or column, the unsolved cells holding the values completing
+			// the pattern and the same extra value c: one of them is c
+			u1, u2 := unsolved[0], unsolved[1]
+			if u1+u2 == 3 {
+				return
+			}
+			a, b := required(u1), required(u2)
+			c1 := grid.Get(cells[u1].Row, cells[u1].Col).Difference(NewValueSet(a))
+			c2 := grid.Get(cells[u2].Row, cells[u2].Col).Difference(NewValueSet(b))
+			if a == b || c1 != c2 || c1.Length() != 1 || grid.Get(cells[u1].Row, cells[u1].Col).Length() != 2 || grid.Get(cells[u2].Row, cells[u2].Col).Length() != 2 {
+				return
+			}
+			for _, cell := range s.seenByAll([]Cell{cells[u1], cells[u2]}) {
+				s.removeCandidates(&step, cell, c1, fmt.Sprintf("avoidable rectangle type 2, avoids %s", deadlyPattern(cells, NewValueSet(a, b))))
+			}
+		}
+	})
+	return step
+}
+
+// ResolveBUGOptions based on https://www.sudokuwiki.org/BUG
+//
+// A Bivalue Universal Grave is a grid whose unsolved cells all hold two candidates, each candidate appearing twice
+// in each house: it has either no solution or two. When all unsolved cells but one are bivalue, and removing one
+// candidate of this cell gives a BUG, this candidate is the cell value. Only valid for puzzles having a unique
+// solution
+func (s *Sudoku) ResolveBUGOptions() Step {
+	step := Step{Technique: "BUG+1"}
+	grid := s.Candidates()
+	var extra *Option
+	options := grid.Options()
+	for i, option := range options {
+		switch {
+		case option.Length() == 2:
+		case option.Length() == 3 && extra == nil:
+			extra = &options[i]
+		default:
+			return step
+		}
+	}
+	if extra == nil {
+		return step
+	}
+	cell := extra.cell()
+Values:
+	for _, v := range extra.GetValues() {
+		// once v removed from the extra cell, each candidate must appear twice (or not at all) in each house
+		for _, house := range s.Houses() {
+			for value := 1; value <= s.size; value++ {
+				nb := len(s.valueCells(house, value))
+				if value == v && s.geo.house(house.Kind, cell.Row, cell.Col) == house {
+					nb--
+				}
+				if nb != 0 && nb != 2 {
+					continue Values
+				}
+			}
+		}
+		if s.place(&step, cell, v) {
+			step.Details = append(step.Details, fmt.Sprintf("%s in %s (BUG+1, avoids a grid where each candidate appears twice in each house)", NewValueSet(v).String(), extra.String()))
+		}
+		break
+	}
+	return step
+}
@@ -0,0 +1,203 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+// checkUniquenessStep fails the test if step eliminations or description differ from the expected ones
+func checkUniquenessStep(t *testing.T, step Step, eliminations []Elimination, description string) {
+	t.Helper()
+	if !reflect.DeepEqual(step.Eliminations, eliminations) {
+		t.Errorf("got eliminations %v, expected %v", step.Eliminations, eliminations)
+	}
+	if res := step.String(); res != description {
+		t.Errorf("unexpected step %s", res)
+	}
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type1(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2 in boxes 1 and 2, D2 being the only cell with other candidates
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2)
+	restrictCell(&s, 1, 0, 1, 2)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 3}, Values: []int{1, 2}}},
+		"Unique Rectangle: x1 ([1, 2] from D2[1, 2, 3, 4, 5, 6, 7, 8, 9] (unique rectangle type 1, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type2(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, with extra candidate 3 in A2 and D2: one of them is 3, removed from G2 in row 2
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2)
+	restrictCell(&s, 1, 0, 1, 2, 3)
+	restrictCell(&s, 1, 3, 1, 2, 3)
+	restrictLine(&s, HouseRow, 1, 3, 0, 3, 6)
+	restrictCell(&s, 1, 6, 3, 7, 8)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 6}, Values: []int{3}}},
+		"Unique Rectangle: x1 ([3] from G2[3, 7, 8] (unique rectangle type 2, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type3(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, with extra candidates 3 in A2 and 4 in D2: acting as a single [3, 4] cell, they make
+	// a naked pair with G2 in row 2
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2)
+	restrictCell(&s, 1, 0, 1, 2, 3)
+	restrictCell(&s, 1, 3, 1, 2, 4)
+	restrictCell(&s, 1, 6, 3, 4)
+	restrictLine(&s, HouseRow, 1, 3, 0, 6, 7)
+	restrictLine(&s, HouseRow, 1, 4, 3, 6)
+	restrictCell(&s, 1, 7, 3, 5, 6)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 7}, Values: []int{3}}},
+		"Unique Rectangle: x1 ([3] from H2[3, 5, 6] (unique rectangle type 3, avoids deadly pattern [1, 2] in A1, D1, A2, D2, naked [3, 4] with G2[3, 4] in row 2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type4(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, 1 being only possible in A2 and D2 in row 2: one of them is 1, so neither is 2
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2)
+	restrictCell(&s, 1, 0, 1, 2, 5)
+	restrictCell(&s, 1, 3, 1, 2, 6)
+	restrictLine(&s, HouseRow, 1, 1, 0, 3)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 0}, Values: []int{2}}, {Cell: Cell{1, 3}, Values: []int{2}}},
+		"Unique Rectangle: x2 ([2] from A2[1, 2, 5] (unique rectangle type 4, avoids deadly pattern [1, 2] in A1, D1, A2, D2), "+
+			"[2] from D2[1, 2, 6] (unique rectangle type 4, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type5(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, with extra candidate 3 in diagonal cells D1 and A2: one of them is 3, removed from F2
+	// seeing both
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 1, 3, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2, 3)
+	restrictCell(&s, 1, 0, 1, 2, 3)
+	for _, cell := range []Cell{{0, 1}, {0, 2}, {1, 4}} {
+		s.Candidates().Eliminate(cell.Row, cell.Col, NewValueSet(3))
+	}
+	restrictCell(&s, 1, 5, 3, 7, 8)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 5}, Values: []int{3}}},
+		"Unique Rectangle: x1 ([3] from F2[3, 7, 8] (unique rectangle type 5, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_Type6(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, 1 being only possible in rectangle cells in rows 1 and 2: it is in the diagonal
+	// bivalue cells A1 and D2, as D1 and A2 holding 1 would make the deadly pattern
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 1, 3, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2, 3)
+	restrictCell(&s, 1, 0, 1, 2, 4)
+	restrictLine(&s, HouseRow, 0, 1, 0, 3)
+	restrictLine(&s, HouseRow, 1, 1, 0, 3)
+
+	checkUniquenessStep(t, s.ResolveUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{0, 3}, Values: []int{1}}, {Cell: Cell{1, 0}, Values: []int{1}}},
+		"Unique Rectangle: x2 ([1] from D1[1, 2, 3] (unique rectangle type 6, avoids deadly pattern [1, 2] in A1, D1, A2, D2), "+
+			"[1] from A2[1, 2, 4] (unique rectangle type 6, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveHiddenUniqueRectangleOptions(t *testing.T) {
+	s := New(9)
+	// rectangle A1, D1, A2, D2, A1 being bivalue and 1 only possible in rectangle cells in row 2 and column D: D2
+	// can't be 2
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 3, 1, 2, 5)
+	restrictCell(&s, 1, 0, 1, 2, 6)
+	restrictCell(&s, 1, 3, 1, 2, 7)
+	restrictLine(&s, HouseRow, 1, 1, 0, 3)
+	restrictLine(&s, HouseColumn, 3, 1, 0, 1)
+
+	checkUniquenessStep(t, s.ResolveHiddenUniqueRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 3}, Values: []int{2}}},
+		"Hidden Unique Rectangle: x1 ([2] from D2[1, 2, 7] (hidden unique rectangle, [1] only in rectangle cells of row 2 and column D, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveAvoidableRectangleOptions_Type1(t *testing.T) {
+	s := New(9)
+	s.Candidates() // values set afterwards are solved cells, not givens
+	// A1, D1 and A2 solved: D2 can't be 1, completing the deadly pattern
+	s.SetValue(1, 0, 0)
+	s.SetValue(2, 0, 3)
+	s.SetValue(2, 1, 0)
+
+	checkUniquenessStep(t, s.ResolveAvoidableRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 3}, Values: []int{1}}},
+		"Avoidable Rectangle: x1 ([1] from D2[1, 3, 4, 5, 6, 7, 8, 9] (avoidable rectangle type 1, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveAvoidableRectangleOptions_Type2(t *testing.T) {
+	s := New(9)
+	s.Candidates() // values set afterwards are solved cells, not givens
+	// A1 and D1 solved, A2 and D2 holding the values completing the deadly pattern and extra candidate 3: one of
+	// them is 3, removed from G2 in row 2
+	s.SetValue(1, 0, 0)
+	s.SetValue(2, 0, 3)
+	restrictCell(&s, 1, 0, 2, 3)
+	restrictCell(&s, 1, 3, 1, 3)
+	restrictLine(&s, HouseRow, 1, 3, 0, 3, 6)
+	restrictCell(&s, 1, 6, 3, 7, 8)
+
+	checkUniquenessStep(t, s.ResolveAvoidableRectangleOptions(),
+		[]Elimination{{Cell: Cell{1, 6}, Values: []int{3}}},
+		"Avoidable Rectangle: x1 ([3] from G2[3, 7, 8] (avoidable rectangle type 2, avoids deadly pattern [1, 2] in A1, D1, A2, D2))")
+}
+
+func TestSudoku_ResolveUniqueRectangleOptions_NotInTwoBoxes(t *testing.T) {
+	s := New(9)
+	// rectangle A1, B1, A2, B2 lies in a single box: it is not a deadly pattern
+	restrictCell(&s, 0, 0, 1, 2)
+	restrictCell(&s, 0, 1, 1, 2)
+	restrictCell(&s, 1, 0, 1, 2)
+
+	if step := s.ResolveUniqueRectangleOptions(); len(step.Eliminations) > 0 {
+		t.Errorf("unexpected deduction %s", step.String())
+	}
+}
+
+func TestSudoku_ResolveUniquenessOptions_Puzzles(t *testing.T) {
+	solver := Solver{AssumeUnique: true}
+	checkSolverTechnique(t, solver, StrategyUniqueRectangle,
+		"..73.18....6.7..5.5..6..........3..8.9....2..7.2.8........1...4.....2...91....56.",
+		"..4.7...2.25...8...1.89.4...42..8.5.6......2....1.7..4.37..........1973.....5....",
+		"8.4..6.7.5...3.9.8......1...6..8.29.2..3.......1.4.8...4...3.....62...3...5...7.4")
+	checkSolverTechnique(t, solver, StrategyBUG,
+		".....39..8.2.4....7.....53.9....1...2...6...5...5...1.5.4.2.39..8.7.......7..4...",
+		"..6.3..........617...4...5..94..........295..8.5.7.2..4....1.9..72.6....1........")
+}
+
+func TestSolver_AssumeUnique(t *testing.T) {
+	grid := "..73.18....6.7..5.5..6..........3..8.9....2..7.2.8........1...4.....2...91....56."
+	for _, assumeUnique := range []bool{false, true} {
+		s, err := Parse(grid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		used := false
+		Solver{AssumeUnique: assumeUnique, Observer: ObserverFunc(func(e Event) {
+			if e.Kind != EventStep {
+				return
+			}
+			if st, found := DefaultRegistry().Get(e.Step.Technique); found && requiresUniqueness(st) {
+				used = true
+			}
+		})}.Solve(&s)
+		if used != assumeUnique {
+			t.Errorf("AssumeUnique %v: uniqueness strategies used %v", assumeUnique, used)
+		}
+	}
+}
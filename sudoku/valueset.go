@@ -2,64 +2,106 @@ package sudoku
 
 import (
 	"fmt"
-	"sort"
+	"math/bits"
 	"strconv"
 	"strings"
 )
 
-type ValueSet map[int]struct{}
+// ValueSet is a set of values, stored as a bitmask (bit v is set when value v belongs to the set).
+//
+// A 32 bits word holds all values of every supported grid size (up to maxSize = 25), so a single fixed width
+// is used whatever the grid size
+type ValueSet uint32
 
 func NewValueSet(values ...int) ValueSet {
-	pv := make(ValueSet)
+	var pv ValueSet
 	for _, v := range values {
-		pv[v] = struct{}{}
+		pv.Add(v)
 	}
 	return pv
 }
 
+// fullValueSet returns the ValueSet holding all values of a grid of given size
+func fullValueSet(size int) ValueSet {
+	return ValueSet((uint32(1)<<size - 1) << 1)
+}
+
 func (pv ValueSet) String() string {
 	res := []string{}
-	for v, _ := range pv {
-		res = append(res, strconv.Itoa(v))
+	for cur := pv; cur != 0; cur &= cur - 1 {
+		res = append(res, strconv.Itoa(bits.TrailingZeros32(uint32(cur))))
 	}
-	sort.Strings(res)
 	return fmt.Sprintf("[%s]", strings.Join(res, ", "))
 }
 
+// Length returns the number of values of the receiver ValueSet `pv`.
+func (pv ValueSet) Length() int {
+	return bits.OnesCount32(uint32(pv))
+}
+
+// Has returns true if value belongs to the receiver ValueSet `pv`.
+func (pv ValueSet) Has(value int) bool {
+	return pv&(1<<value) != 0
+}
+
+// First returns the smallest value of the receiver ValueSet `pv` (0 if empty).
+func (pv ValueSet) First() int {
+	if pv == 0 {
+		return 0
+	}
+	return bits.TrailingZeros32(uint32(pv))
+}
+
+// GetValues returns the values of the receiver ValueSet `pv`, in ascending order.
 func (pv ValueSet) GetValues() []int {
-	res := []int{}
-	for v, _ := range pv {
-		res = append(res, v)
+	res := make([]int, 0, pv.Length())
+	for cur := pv; cur != 0; cur &= cur - 1 {
+		res = append(res, bits.TrailingZeros32(uint32(cur)))
 	}
-	sort.Ints(res)
 	return res
 }
 
-func (pv ValueSet) Contains(ovs ValueSet) bool {
-	for ov := range ovs {
-		if _, found := pv[ov]; !found {
-			return false
-		}
+// Each calls fn for each value of the receiver ValueSet `pv`, in ascending order.
+func (pv ValueSet) Each(fn func(value int)) {
+	for cur := pv; cur != 0; cur &= cur - 1 {
+		fn(bits.TrailingZeros32(uint32(cur)))
 	}
-	return true
+}
+
+func (pv ValueSet) Contains(ovs ValueSet) bool {
+	return pv&ovs == ovs
+}
+
+// Union returns a new ValueSet holding elements of the receiver ValueSet `pv` or of the given ValueSet `ovs`.
+func (pv ValueSet) Union(ovs ValueSet) ValueSet {
+	return pv | ovs
+}
+
+// Intersection returns a new ValueSet holding elements of both the receiver ValueSet `pv` and the given ValueSet `ovs`.
+func (pv ValueSet) Intersection(ovs ValueSet) ValueSet {
+	return pv & ovs
+}
+
+// Difference returns a new ValueSet holding elements of the receiver ValueSet `pv` not included in the given ValueSet `ovs`.
+func (pv ValueSet) Difference(ovs ValueSet) ValueSet {
+	return pv &^ ovs
+}
+
+// Add adds value to the receiver ValueSet `pv`.
+func (pv *ValueSet) Add(value int) {
+	*pv |= 1 << value
 }
 
 // RemoveSet removes all elements of the given ValueSet `ovs` from the receiver ValueSet `pv`.
-func (pv ValueSet) RemoveSet(ovs ValueSet) {
-	for ov := range ovs {
-		delete(pv, ov)
-	}
+func (pv *ValueSet) RemoveSet(ovs ValueSet) {
+	*pv &^= ovs
 }
 
 // RemoveButSet removes all elements not included in the given ValueSet `ovs` from the receiver ValueSet `pv`.
-func (pv ValueSet) RemoveButSet(ovs ValueSet) {
-	for v, _ := range pv {
-		if _, found := ovs[v]; !found {
-			delete(pv, v)
-		}
-	}
+func (pv *ValueSet) RemoveButSet(ovs ValueSet) {
+	*pv &= ovs
 }
 
-func (pv ValueSet) RemoveValue(value int) {
-	delete(pv, value)
+func (pv *ValueSet) RemoveValue(value int) {
+	*pv &^= 1 << value
 }
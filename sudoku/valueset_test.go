@@ -0,0 +1,41 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValueSet(t *testing.T) {
+	a := NewValueSet(1, 3, 5, 25)
+	b := NewValueSet(3, 4, 5)
+	if a.Length() != 4 || !a.Has(25) || a.Has(2) || a.First() != 1 {
+		t.Errorf("unexpected set %s", a.String())
+	}
+	if got := a.Union(b).GetValues(); !reflect.DeepEqual(got, []int{1, 3, 4, 5, 25}) {
+		t.Errorf("unexpected union %v", got)
+	}
+	if got := a.Intersection(b); got != NewValueSet(3, 5) || !a.Contains(got) || a.Contains(b) {
+		t.Errorf("unexpected intersection %s", got.String())
+	}
+	if got := a.Difference(b).String(); got != "[1, 25]" {
+		t.Errorf("unexpected difference %s", got)
+	}
+	values := []int{}
+	b.Each(func(v int) { values = append(values, v) })
+	if !reflect.DeepEqual(values, []int{3, 4, 5}) {
+		t.Errorf("unexpected iteration %v", values)
+	}
+	a.RemoveButSet(b)
+	b.RemoveSet(a)
+	b.RemoveValue(4)
+	if a != NewValueSet(3, 5) || b != 0 || fullValueSet(9).Length() != 9 || fullValueSet(9).Has(0) {
+		t.Errorf("unexpected sets %s %s", a.String(), b.String())
+	}
+}
+
+func BenchmarkSudoku_GetValid(b *testing.B) {
+	s, _ := Parse(testPuzzles[5].grid)
+	for i := 0; i < b.N; i++ {
+		s.GetValid(4, 4)
+	}
+}
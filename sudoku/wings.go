@@ -0,0 +1,150 @@
+package sudoku
+
+import "fmt"
+
+// bivalueCells returns the cells having exactly 2 candidates
+func (s *Sudoku) bivalueCells() []Cell {
+	res := []Cell{}
+	for _, option := range s.Candidates().Options() {
+		if option.Length() == 2 {
+			res = append(res, option.cell())
+		}
+	}
+	return res
+}
+
+// seenByAll returns the cells seeing all given cells
+func (s *Sudoku) seenByAll(cells []Cell) []Cell {
+	res := []Cell{}
+Peers:
+	for _, peer := range s.Peers(cells[0].Row, cells[0].Col) {
+		for _, cell := range cells[1:] {
+			if !s.geo.sees(peer, cell) {
+				continue Peers
+			}
+		}
+		res = append(res, peer)
+	}
+	return res
+}
+
+// eliminateWing removes wing value from the cells seeing all seen cells, and records wing in step if it made
+// eliminations
+func (s *Sudoku) eliminateWing(step *Step, wing Wing, seen []Cell, reason string) {
+	grid := s.Candidates()
+	for _, cell := range s.seenByAll(seen) {
+		if !grid.Has(cell.Row, cell.Col, wing.Value) {
+			continue
+		}
+		option := grid.option(cell)
+		s.eliminate(step, cell, NewValueSet(wing.Value))
+		wing.Eliminations = append(wing.Eliminations, Elimination{Cell: cell, Values: []int{wing.Value}})
+		step.Details = append(step.Details, fmt.Sprintf("%s from %s (%s)", NewValueSet(wing.Value).String(), option.String(), reason))
+	}
+	if len(wing.Eliminations) > 0 {
+		step.Wings = append(step.Wings, wing)
+	}
+}
+
+// ResolveXYWingOptions based on https://www.sudokuwiki.org/Y_Wing_Strategy
+//
+// A pivot cell [x, y] sees two pincer cells [x, z] and [y, z]: whatever the pivot value, one of the pincers is z, so
+// z can be removed from the cells seeing both pincers
+func (s *Sudoku) ResolveXYWingOptions() Step {
+	step := Step{Technique: "XY-Wing"}
+	grid := s.Candidates()
+	bivalues := s.bivalueCells()
+	for _, pivot := range bivalues {
+		pivotValues := grid.Get(pivot.Row, pivot.Col)
+		for _, a := range bivalues {
+			aValues := grid.Get(a.Row, a.Col)
+			// first pincer holds the smallest pivot value, so that each wing is found once
+			if !s.geo.sees(pivot, a) || aValues.Intersection(pivotValues) != NewValueSet(pivotValues.First()) {
+				continue
+			}
+			z := aValues.Difference(pivotValues)
+			if z.Length() != 1 { // pincer candidates changed by a previous elimination
+				continue
+			}
+			for _, b := range bivalues {
+				bValues := grid.Get(b.Row, b.Col)
+				if !s.geo.sees(pivot, b) || bValues != pivotValues.Difference(aValues).Union(z) {
+					continue
+				}
+				wing := Wing{Pivot: []Cell{pivot}, Pincers: [2]Cell{a, b}, Value: z.First()}
+				reason := fmt.Sprintf("pivot %s / pincers %s, %s", grid.option(pivot).String(), grid.option(a).String(), grid.option(b).String())
+				s.eliminateWing(&step, wing, []Cell{a, b}, reason)
+			}
+		}
+	}
+	return step
+}
+
+// ResolveXYZWingOptions based on https://www.sudokuwiki.org/XYZ_Wing
+//
+// A pivot cell [x, y, z] sees two pincer cells [x, z] and [y, z]: one of the three cells is z, so z can be removed
+// from the cells seeing the pivot and both pincers
+func (s *Sudoku) ResolveXYZWingOptions() Step {
+	step := Step{Technique: "XYZ-Wing"}
+	grid := s.Candidates()
+	bivalues := s.bivalueCells()
+	for _, option := range grid.Options() {
+		if option.Length() != 3 {
+			continue
+		}
+		pivot := option.cell()
+		for i, a := range bivalues {
+			aValues := grid.Get(a.Row, a.Col)
+			if aValues.Length() != 2 || !s.geo.sees(pivot, a) || !option.option.Contains(aValues) {
+				continue
+			}
+			for _, b := range bivalues[i+1:] {
+				bValues := grid.Get(b.Row, b.Col)
+				if bValues.Length() != 2 || !s.geo.sees(pivot, b) || !option.option.Contains(bValues) || aValues == bValues {
+					continue
+				}
+				wing := Wing{Pivot: []Cell{pivot}, Pincers: [2]Cell{a, b}, Value: aValues.Intersection(bValues).First()}
+				reason := fmt.Sprintf("pivot %s / pincers %s, %s", option.String(), grid.option(a).String(), grid.option(b).String())
+				s.eliminateWing(&step, wing, []Cell{pivot, a, b}, reason)
+			}
+		}
+	}
+	return step
+}
+
+// ResolveWWingOptions based on https://www.sudokuwiki.org/W_Wing_Strategy
+//
+// Two pincer cells [x, y] not seeing each other are linked by a strong link on x (a house where x is only possible
+// in two cells, each of them seeing one of the pincers): one of the pincers is y, so y can be removed from the cells
+// seeing both pincers
+func (s *Sudoku) ResolveWWingOptions() Step {
+	step := Step{Technique: "W-Wing"}
+	grid := s.Candidates()
+	bivalues := s.bivalueCells()
+	for i, a := range bivalues {
+		values := grid.Get(a.Row, a.Col)
+		for _, b := range bivalues[i+1:] {
+			if values.Length() != 2 || grid.Get(b.Row, b.Col) != values || s.geo.sees(a, b) {
+				continue
+			}
+			for _, x := range values.GetValues() {
+				for _, house := range s.Houses() {
+					link := s.valueCells(house, x)
+					if len(link) != 2 || link[0] == a || link[0] == b || link[1] == a || link[1] == b {
+						continue
+					}
+					if !s.geo.sees(link[0], a) || !s.geo.sees(link[1], b) {
+						link[0], link[1] = link[1], link[0]
+						if !s.geo.sees(link[0], a) || !s.geo.sees(link[1], b) {
+							continue
+						}
+					}
+					wing := Wing{Pivot: link, Pincers: [2]Cell{a, b}, Value: values.Difference(NewValueSet(x)).First()}
+					reason := fmt.Sprintf("pincers %s, %s / strong link %s in %s (%s, %s)", grid.option(a).String(), grid.option(b).String(), NewValueSet(x).String(), house.String(), link[0].String(), link[1].String())
+					s.eliminateWing(&step, wing, []Cell{a, b}, reason)
+				}
+			}
+		}
+	}
+	return step
+}
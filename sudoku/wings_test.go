@@ -0,0 +1,73 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+// restrictCell removes all candidates of cell (row, col) but given values
+func restrictCell(s *Sudoku, row, col int, values ...int) {
+	s.Candidates().Eliminate(row, col, fullValueSet(s.size).Difference(NewValueSet(values...)))
+}
+
+func TestSudoku_ResolveXYWingOptions(t *testing.T) {
+	s := New(9)
+	restrictCell(&s, 0, 0, 1, 2) // pivot A1
+	restrictCell(&s, 4, 0, 1, 3) // pincer A5, seeing pivot in column A
+	restrictCell(&s, 1, 2, 2, 3) // pincer C2, seeing pivot in box 1
+
+	step := s.ResolveXYWingOptions()
+	if len(step.Wings) != 1 {
+		t.Fatalf("expected 1 wing, got %s", step.String())
+	}
+	expect := Wing{
+		Pivot:   []Cell{{0, 0}},
+		Pincers: [2]Cell{{4, 0}, {1, 2}},
+		Value:   3,
+		Eliminations: []Elimination{
+			{Cell: Cell{3, 2}, Values: []int{3}},
+			{Cell: Cell{4, 2}, Values: []int{3}},
+			{Cell: Cell{5, 2}, Values: []int{3}},
+			{Cell: Cell{1, 0}, Values: []int{3}},
+			{Cell: Cell{2, 0}, Values: []int{3}},
+		},
+	}
+	// cells seeing both pincers: C4, C5 and C6 (box 4 and column C), A2 and A3 (column A and box 1)
+	if !reflect.DeepEqual(step.Wings[0], expect) {
+		t.Errorf("got wing %+v, expected %+v", step.Wings[0], expect)
+	}
+	if !reflect.DeepEqual(step.Eliminations, expect.Eliminations) {
+		t.Errorf("got eliminations %v, expected %v", step.Eliminations, expect.Eliminations)
+	}
+}
+
+func TestSudoku_ResolveWWingOptions(t *testing.T) {
+	s := New(9)
+	restrictCell(&s, 0, 0, 4, 7) // pincer A1
+	restrictCell(&s, 8, 4, 4, 7) // pincer E9
+	// strong link on 4 in row 5, between A5 (seeing A1) and E5 (seeing E9)
+	for c := 1; c < 9; c++ {
+		if c != 4 {
+			s.Candidates().Eliminate(4, c, NewValueSet(4))
+		}
+	}
+
+	step := s.ResolveWWingOptions()
+	if len(step.Wings) != 1 {
+		t.Fatalf("expected 1 wing, got %s", step.String())
+	}
+	wing := step.Wings[0]
+	if !reflect.DeepEqual(wing.Pivot, []Cell{{4, 0}, {4, 4}}) || wing.Value != 7 {
+		t.Errorf("unexpected wing %+v", wing)
+	}
+	// cells seeing both A1 and E9: E1 and A9
+	if len(wing.Eliminations) != 2 || wing.Eliminations[0].Cell != (Cell{0, 4}) || wing.Eliminations[1].Cell != (Cell{8, 0}) {
+		t.Errorf("unexpected eliminations %v", wing.Eliminations)
+	}
+}
+
+func TestSudoku_ResolveWingOptions_Puzzles(t *testing.T) {
+	checkTechnique(t, StrategyXYWing, ".2..13.......7..965......1........7..946...5..8...76....3.8.......59.2..249......")
+	checkTechnique(t, StrategyXYZWing, "..1..9..4.5...6.2.72.4....64.7......28.....47.....1...6..1.73.2...82.............")
+	checkTechnique(t, StrategyWWing, "...........296....8...7.5.1.9.....3.13..9..6...41.89........2....5643...76.....4.")
+}